@@ -17,10 +17,11 @@ limitations under the License.
 package main
 
 import (
-	"bufio"
+	"bytes"
 	"flag"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"os"
 	"os/signal"
 	"path"
@@ -33,14 +34,39 @@ import (
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/klog/v2"
 
+	"sigs.k8s.io/ingress-controller-conformance/test/conformance/appprotocol"
+	"sigs.k8s.io/ingress-controller-conformance/test/conformance/backendfailures"
+	"sigs.k8s.io/ingress-controller-conformance/test/conformance/backendresponse"
+	"sigs.k8s.io/ingress-controller-conformance/test/conformance/backendtls"
+	"sigs.k8s.io/ingress-controller-conformance/test/conformance/certificatechain"
+	"sigs.k8s.io/ingress-controller-conformance/test/conformance/certificaterotation"
 	"sigs.k8s.io/ingress-controller-conformance/test/conformance/defaultbackend"
+	"sigs.k8s.io/ingress-controller-conformance/test/conformance/defaultbackendrules"
+	"sigs.k8s.io/ingress-controller-conformance/test/conformance/dualstack"
+	"sigs.k8s.io/ingress-controller-conformance/test/conformance/endpointreadiness"
+	"sigs.k8s.io/ingress-controller-conformance/test/conformance/forwardedheaders"
+	"sigs.k8s.io/ingress-controller-conformance/test/conformance/hostheader"
 	"sigs.k8s.io/ingress-controller-conformance/test/conformance/hostrules"
+	"sigs.k8s.io/ingress-controller-conformance/test/conformance/implementationspecific"
 	"sigs.k8s.io/ingress-controller-conformance/test/conformance/ingressclass"
+	"sigs.k8s.io/ingress-controller-conformance/test/conformance/ingressstatus"
+	"sigs.k8s.io/ingress-controller-conformance/test/conformance/invalidtlssecrets"
 	"sigs.k8s.io/ingress-controller-conformance/test/conformance/loadbalancing"
+	"sigs.k8s.io/ingress-controller-conformance/test/conformance/pathnormalization"
 	"sigs.k8s.io/ingress-controller-conformance/test/conformance/pathrules"
+	"sigs.k8s.io/ingress-controller-conformance/test/conformance/querystring"
+	"sigs.k8s.io/ingress-controller-conformance/test/conformance/requestbody"
+	"sigs.k8s.io/ingress-controller-conformance/test/conformance/responsestreaming"
+	"sigs.k8s.io/ingress-controller-conformance/test/conformance/rollingupdate"
+	"sigs.k8s.io/ingress-controller-conformance/test/conformance/sessionaffinity"
+	"sigs.k8s.io/ingress-controller-conformance/test/conformance/tls"
+	"sigs.k8s.io/ingress-controller-conformance/test/conformance/tlspolicy"
+	"sigs.k8s.io/ingress-controller-conformance/test/conformance/wildcardhostprecedence"
+	"sigs.k8s.io/ingress-controller-conformance/test/distribution"
 	"sigs.k8s.io/ingress-controller-conformance/test/http"
 	"sigs.k8s.io/ingress-controller-conformance/test/kubernetes"
 	"sigs.k8s.io/ingress-controller-conformance/test/kubernetes/templates"
+	"sigs.k8s.io/ingress-controller-conformance/test/report"
 )
 
 var (
@@ -49,6 +75,9 @@ var (
 	godogStopOnFailure bool
 	godogNoColors      bool
 	godogOutput        string
+
+	// ingressAddressMap value of the ingress-address-map flag
+	ingressAddressMap string
 )
 
 func TestMain(m *testing.M) {
@@ -63,7 +92,23 @@ func TestMain(m *testing.M) {
 	flag.StringVar(&kubernetes.IngressClassValue, "ingress-class", "conformance", "Sets the value of the annotation kubernetes.io/ingress.class in Ingress definitions")
 	flag.DurationVar(&kubernetes.WaitForIngressAddressTimeout, "wait-time-for-ingress-status", 5*time.Minute, "Maximum wait time for valid ingress status value")
 	flag.DurationVar(&kubernetes.WaitForEndpointsTimeout, "wait-time-for-ready", 5*time.Minute, "Maximum wait time for ready endpoints")
+	flag.DurationVar(&kubernetes.WaitForCertificateRotationTimeout, "wait-time-for-certificate-rotation", 2*time.Minute, "Maximum wait time for the ingress controller to present an updated certificate")
+	flag.DurationVar(&kubernetes.IngressStatusStabilityPeriod, "ingress-status-stability-period", 30*time.Second, "Time the addresses in the Ingress status must not change after the Ingress is updated")
+	flag.StringVar(&kubernetes.IngressAddressFamily, "address-family", kubernetes.AnyAddressFamily, "Family of the address in the Ingress status used to send requests. Valid values are any, ipv4, ipv6, hostname and dual (requests are sent using an IPv4 and an IPv6 address). Features tagged @dual-stack are skipped unless dual")
+	flag.StringVar(&http.HTTPAddressOverride, "ingress-http-address", "", "Address (host or host:port) where HTTP requests are sent instead of the address in the Ingress status")
+	flag.StringVar(&http.HTTPSAddressOverride, "ingress-https-address", "", "Address (host or host:port) where HTTPS requests are sent instead of the address in the Ingress status")
+	flag.StringVar(&ingressAddressMap, "ingress-address-map", "", "Comma separated list of status=host entries mapping addresses in the Ingress status to the hosts where requests are sent, using the port of the scheme")
+	flag.StringVar(&http.DNSServer, "dns-server", "", "Address (host:port) of the DNS server used to resolve hostnames instead of the system resolver")
 	flag.BoolVar(&http.EnableDebug, "enable-http-debug", false, "Enable dump of requests and responses of HTTP requests (useful for debug)")
+	flag.DurationVar(&http.SlowBackendDelay, "slow-backend-delay", 90*time.Second, "Time the backend service waits before responding to exceed the timeout of the ingress controller for requests sent to the backend (backend failures)")
+	flag.DurationVar(&http.StreamTimingTolerance, "stream-timing-tolerance", 500*time.Millisecond, "Time the pieces of a streamed response may arrive closer together than they were sent by the backend service (response streaming)")
+	flag.Float64Var(&http.MaxLoadErrorRate, "max-load-error-rate", 1, "Maximum percentage of failed requests tolerated while sending requests in the background (rolling update)")
+	flag.Float64Var(&distribution.MaxRatio, "max-load-distribution-ratio", 3, "Maximum ratio between the number of requests served by the pods serving the most and the fewest requests (load balancing)")
+	flag.Float64Var(&distribution.Significance, "load-distribution-significance", 0.001, "Significance level of the chi-square test checking requests are distributed uniformly between pods (load balancing)")
+	flag.StringVar(&http.AcceptedTLSVersions, "accepted-tls-versions", "", "Comma separated list of TLS versions (TLS1.0, TLS1.1, TLS1.2 or TLS1.3) the ingress controller must accept")
+	flag.StringVar(&http.RejectedTLSVersions, "rejected-tls-versions", "", "Comma separated list of TLS versions (TLS1.0, TLS1.1, TLS1.2 or TLS1.3) the ingress controller must reject")
+	flag.StringVar(&kubernetes.EchoContainer, "echoserver-image", kubernetes.DefaultEchoContainer, "Container image of the echoserver used as backend. Features tagged @unreleased-echoserver are skipped with the default image")
+	flag.DurationVar(&kubernetes.EchoDrainPeriod, "echoserver-drain-period", 10*time.Second, "Time the echoserver keeps serving requests after receiving the SIGTERM signal (rolling update)")
 	flag.BoolVar(&kubernetes.EnableOutputYamlDefinitions, "enable-output-yaml-definitions", false, "Dump yaml definitions of Kubernetes objects before creation")
 
 	flag.Parse()
@@ -73,7 +118,33 @@ func TestMain(m *testing.M) {
 		klog.Fatalf("the godog format '%v' is not supported", godogFormat)
 	}
 
-	err := setup()
+	addressMap, err := http.ParseAddressMap(ingressAddressMap)
+	if err != nil {
+		klog.Fatal(err)
+	}
+
+	http.AddressMap = addressMap
+
+	validAddressFamilies := sets.NewString(kubernetes.AnyAddressFamily, kubernetes.IPv4AddressFamily, kubernetes.IPv6AddressFamily, kubernetes.HostnameAddressFamily, kubernetes.DualStackAddressFamily)
+	if !validAddressFamilies.Has(kubernetes.IngressAddressFamily) {
+		klog.Fatalf("the address family '%v' is not supported", kubernetes.IngressAddressFamily)
+	}
+
+	if kubernetes.IngressAddressFamily == kubernetes.DualStackAddressFamily && http.AddressOverrideEnabled() {
+		klog.Fatalf("the address family '%v' requires sending requests to the addresses of the Ingress status. Use --ingress-address-map instead of --ingress-http-address and --ingress-https-address",
+			kubernetes.IngressAddressFamily)
+	}
+
+	// the released echoserver image does not implement the features used by these scenarios
+	if kubernetes.EchoContainer == kubernetes.DefaultEchoContainer {
+		godogTags = excludeTag(godogTags, "@unreleased-echoserver")
+	}
+
+	if kubernetes.IngressAddressFamily != kubernetes.DualStackAddressFamily {
+		godogTags = excludeTag(godogTags, "@dual-stack")
+	}
+
+	err = setup()
 	if err != nil {
 		klog.Fatal(err)
 	}
@@ -87,6 +158,15 @@ func TestMain(m *testing.M) {
 	os.Exit(m.Run())
 }
 
+// excludeTag returns the godog tag expression adding a condition that excludes the scenarios with the tag
+func excludeTag(tags, tag string) string {
+	if tags == "" {
+		return "~" + tag
+	}
+
+	return tags + " && ~" + tag
+}
+
 func setup() error {
 	err := templates.Load()
 	if err != nil {
@@ -104,11 +184,34 @@ func setup() error {
 // Generated code. DO NOT EDIT.
 var (
 	features = map[string]func(*godog.ScenarioContext){
-		"features/default_backend.feature": defaultbackend.InitializeScenario,
-		"features/host_rules.feature":      hostrules.InitializeScenario,
-		"features/path_rules.feature":      pathrules.InitializeScenario,
-		"features/ingress_class.feature":   ingressclass.InitializeScenario,
-		"features/load_balancing.feature":  loadbalancing.InitializeScenario,
+		"features/default_backend.feature":          defaultbackend.InitializeScenario,
+		"features/host_rules.feature":               hostrules.InitializeScenario,
+		"features/path_rules.feature":               pathrules.InitializeScenario,
+		"features/ingress_class.feature":            ingressclass.InitializeScenario,
+		"features/load_balancing.feature":           loadbalancing.InitializeScenario,
+		"features/implementation_specific.feature":  implementationspecific.InitializeScenario,
+		"features/path_normalization.feature":       pathnormalization.InitializeScenario,
+		"features/query_string.feature":             querystring.InitializeScenario,
+		"features/forwarded_headers.feature":        forwardedheaders.InitializeScenario,
+		"features/request_body.feature":             requestbody.InitializeScenario,
+		"features/response_streaming.feature":       responsestreaming.InitializeScenario,
+		"features/backend_response.feature":         backendresponse.InitializeScenario,
+		"features/backend_failures.feature":         backendfailures.InitializeScenario,
+		"features/rolling_update.feature":           rollingupdate.InitializeScenario,
+		"features/endpoint_readiness.feature":       endpointreadiness.InitializeScenario,
+		"features/session_affinity.feature":         sessionaffinity.InitializeScenario,
+		"features/tls.feature":                      tls.InitializeScenario,
+		"features/invalid_tls_secrets.feature":      invalidtlssecrets.InitializeScenario,
+		"features/certificate_rotation.feature":     certificaterotation.InitializeScenario,
+		"features/certificate_chain.feature":        certificatechain.InitializeScenario,
+		"features/tls_policy.feature":               tlspolicy.InitializeScenario,
+		"features/backend_tls.feature":              backendtls.InitializeScenario,
+		"features/app_protocol.feature":             appprotocol.InitializeScenario,
+		"features/host_header.feature":              hostheader.InitializeScenario,
+		"features/wildcard_host_precedence.feature": wildcardhostprecedence.InitializeScenario,
+		"features/default_backend_rules.feature":    defaultbackendrules.InitializeScenario,
+		"features/ingress_status.feature":           ingressstatus.InitializeScenario,
+		"features/dual_stack.feature":               dualstack.InitializeScenario,
 	}
 )
 
@@ -135,21 +238,14 @@ func testFeature(feature string, scenarioInitializer func(*godog.ScenarioContext
 	// default output is stdout
 	testOutput = os.Stdout
 
+	// cucumber reports are buffered to include the observations
+	var cucumberReport bytes.Buffer
 	if godogFormat == "cucumber" {
-		rf := path.Join(godogOutput, fmt.Sprintf("%v-report.json", filepath.Base(feature)))
-		file, err := os.Create(rf)
-		if err != nil {
-			return fmt.Errorf("error creating report file %v: %w", rf, err)
-		}
-
-		defer file.Close()
-
-		writer := bufio.NewWriter(file)
-		defer writer.Flush()
-
-		testOutput = writer
+		testOutput = &cucumberReport
 	}
 
+	defer report.Reset()
+
 	opts := godog.Options{
 		Format:        godogFormat,
 		Paths:         []string{feature},
@@ -161,10 +257,26 @@ func testFeature(feature string, scenarioInitializer func(*godog.ScenarioContext
 	}
 
 	exitCode := godog.TestSuite{
-		Name:                "conformance",
-		ScenarioInitializer: scenarioInitializer,
-		Options:             &opts,
+		Name: "conformance",
+		ScenarioInitializer: func(ctx *godog.ScenarioContext) {
+			report.Register(ctx)
+			scenarioInitializer(ctx)
+		},
+		Options: &opts,
 	}.Run()
+
+	if godogFormat == "cucumber" {
+		err := writeCucumberReport(feature, cucumberReport.Bytes())
+		if err != nil {
+			return err
+		}
+	} else {
+		err := report.Print(os.Stdout)
+		if err != nil {
+			return err
+		}
+	}
+
 	if exitCode > 0 {
 		return fmt.Errorf("unexpected exit code testing %v: %v", feature, exitCode)
 	}
@@ -172,6 +284,21 @@ func testFeature(feature string, scenarioInitializer func(*godog.ScenarioContext
 	return nil
 }
 
+func writeCucumberReport(feature string, data []byte) error {
+	data, err := report.Embed(data)
+	if err != nil {
+		return fmt.Errorf("error adding observations to report of %v: %w", feature, err)
+	}
+
+	rf := path.Join(godogOutput, fmt.Sprintf("%v-report.json", filepath.Base(feature)))
+	err = ioutil.WriteFile(rf, data, 0644)
+	if err != nil {
+		return fmt.Errorf("error creating report file %v: %w", rf, err)
+	}
+
+	return nil
+}
+
 func handleSignals() {
 	signals := make(chan os.Signal, 1)
 	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
@@ -0,0 +1,99 @@
+@sig-network @informational @release-1.19 @unreleased-echoserver
+Feature: Backend protocol selected by the service appProtocol
+  The appProtocol field of a service port indicates the application protocol
+  used by the backend service. The values http, https, kubernetes.io/h2c
+  (HTTP/2 without TLS) and kubernetes.io/ws (WebSocket) allow the ingress
+  controller to select the protocol used to connect to the backend service.
+  
+  The Ingress specification does not require the ingress controller to honour
+  the appProtocol field. Each scenario records the protocol and TLS state
+  of the request received by the backend service in the report, and then
+  checks they match the appProtocol of the service port.
+
+  Scenario Outline: An Ingress with a backend service using the <appProtocol> application protocol
+    Given an Ingress resource in a new random namespace with backend services using the "<appProtocol>" application protocol
+    """
+    apiVersion: networking.k8s.io/v1
+    kind: Ingress
+    metadata:
+      name: app-protocol
+    spec:
+      rules:
+        - host: "app-protocol"
+          http:
+            paths:
+              - path: /
+                pathType: Prefix
+                backend:
+                  service:
+                    name: app-protocol
+                    port:
+                      number: 8080
+    """
+    Then The Ingress status shows the IP address or FQDN where it is exposed
+    When I send a "GET" request to "http://app-protocol" that may fail
+    Then the protocol used to connect to the backend service is recorded
+    And the response status-code must be 200
+    And the response must be served by the "app-protocol" service
+    And the backend service must receive the request using the "<proto>" protocol
+    And the backend service must receive the request "<connection>"
+
+    Examples:
+      | appProtocol       | proto    | connection  |
+      | http              | HTTP/1.1 | unencrypted |
+      | kubernetes.io/h2c | HTTP/2.0 | unencrypted |
+
+  Scenario: An Ingress with a backend service using the https application protocol
+    Given an Ingress resource in a new random namespace with backend services using the "https" application protocol
+    """
+    apiVersion: networking.k8s.io/v1
+    kind: Ingress
+    metadata:
+      name: app-protocol
+    spec:
+      rules:
+        - host: "app-protocol"
+          http:
+            paths:
+              - path: /
+                pathType: Prefix
+                backend:
+                  service:
+                    name: app-protocol
+                    port:
+                      number: 8443
+    """
+    Then The Ingress status shows the IP address or FQDN where it is exposed
+    When I send a "GET" request to "http://app-protocol" that may fail
+    Then the protocol used to connect to the backend service is recorded
+    And the response status-code must be 200
+    And the response must be served by the "app-protocol" service
+    And the backend service must receive the request "encrypted"
+
+  Scenario: An Ingress with a backend service using the kubernetes.io/ws application protocol
+    Given an Ingress resource in a new random namespace with backend services using the "kubernetes.io/ws" application protocol
+    """
+    apiVersion: networking.k8s.io/v1
+    kind: Ingress
+    metadata:
+      name: app-protocol
+    spec:
+      rules:
+        - host: "app-protocol"
+          http:
+            paths:
+              - path: /
+                pathType: Prefix
+                backend:
+                  service:
+                    name: app-protocol
+                    port:
+                      number: 8080
+    """
+    Then The Ingress status shows the IP address or FQDN where it is exposed
+    When I send a WebSocket request to "http://app-protocol/websocket" that may fail
+    Then the protocol used to connect to the backend service is recorded
+    And the response status-code must be 101
+    And the response must be served by the "app-protocol" service
+    And the backend service must receive the request using the "HTTP/1.1" protocol
+    And the backend service must receive the request "unencrypted"
@@ -0,0 +1,80 @@
+@sig-network @informational @release-1.19 @unreleased-echoserver
+Feature: Backend failures
+  A backend service may be unable to handle a request. The service could
+  have no ready endpoints, the pod handling the request could crash or the
+  response could take longer than the timeouts configured in the ingress
+  controller. The delay used to exceed the timeout of the ingress controller
+  is configured with the --slow-backend-delay flag (90 seconds by default).
+  
+  The Ingress specification does not define the response returned by the
+  ingress controller in these cases. Each scenario records the status code
+  (usually 502, 503 or 504) and the latency of the response for a failure mode
+  in the report, and then checks that traffic resumes after the backend
+  service recovers.
+
+  Background:
+    Given an Ingress resource in a new random namespace
+    """
+    apiVersion: networking.k8s.io/v1
+    kind: Ingress
+    metadata:
+      name: backend-failures
+    spec:
+      rules:
+        - host: "backend-failures"
+          http:
+            paths:
+              - path: /
+                pathType: Prefix
+                backend:
+                  service:
+                    name: backend-failures
+                    port:
+                      number: 8080
+    """
+    Then The Ingress status shows the IP address or FQDN where it is exposed
+
+  Scenario: An Ingress with a backend service without ready endpoints
+    Given the "backend-failures" service has no ready endpoints
+    When I send a "GET" request to "http://backend-failures/no-endpoints" that may fail
+    Then the response to the "no-endpoints" failure is recorded
+    When the "backend-failures" service recovers with 1 ready endpoints
+    And I send a "GET" request to "http://backend-failures/recovered"
+    Then the response status-code must be 200
+    And the response must be served by the "backend-failures" service
+
+  Scenario: An Ingress with a backend service that crashes handling the request
+    Given the backend service closes the connection without sending a response
+    When I send a "GET" request to "http://backend-failures/crash" that may fail
+    Then the response to the "crash" failure is recorded
+    When the backend service recovers
+    And I send a "GET" request to "http://backend-failures/recovered"
+    Then the response status-code must be 200
+    And the response must be served by the "backend-failures" service
+
+  Scenario: An Ingress with a backend service that closes the connection in the middle of the response
+    Given the backend service closes the connection in the middle of the response
+    When I send a "GET" request to "http://backend-failures/connection-closed" that may fail
+    Then the response to the "connection-closed" failure is recorded
+    When the backend service recovers
+    And I send a "GET" request to "http://backend-failures/recovered"
+    Then the response status-code must be 200
+    And the response must be served by the "backend-failures" service
+
+  Scenario: An Ingress with a slow backend service
+    Given the backend service delays the response 2 seconds
+    When I send a "GET" request to "http://backend-failures/slow" that may fail
+    Then the response to the "slow" failure is recorded
+    When the backend service recovers
+    And I send a "GET" request to "http://backend-failures/recovered"
+    Then the response status-code must be 200
+    And the response must be served by the "backend-failures" service
+
+  Scenario: An Ingress with a backend service slower than the timeout of the ingress controller
+    Given the backend service delays the response longer than the timeout of the ingress controller
+    When I send a "GET" request to "http://backend-failures/timeout" that may fail
+    Then the response to the "timeout" failure is recorded
+    When the backend service recovers
+    And I send a "GET" request to "http://backend-failures/recovered"
+    Then the response status-code must be 200
+    And the response must be served by the "backend-failures" service
@@ -0,0 +1,60 @@
+@sig-network @informational @release-1.19 @unreleased-echoserver
+Feature: Backend response passthrough
+  The response returned by a backend service may use any status code and
+  include any response header. Some ingress controllers replace error
+  responses of the backend service with their own error pages or remove
+  response headers.
+  
+  The Ingress specification does not define how backend responses must be
+  relayed. This feature does not assert a particular behavior, it records how
+  the controller handles each response in the report.
+
+  Background:
+    Given an Ingress resource in a new random namespace
+    """
+    apiVersion: networking.k8s.io/v1
+    kind: Ingress
+    metadata:
+      name: backend-response
+    spec:
+      rules:
+        - host: "backend-response"
+          http:
+            paths:
+              - path: /
+                pathType: Prefix
+                backend:
+                  service:
+                    name: backend-response
+                    port:
+                      number: 8080
+    """
+    Then The Ingress status shows the IP address or FQDN where it is exposed
+
+  Scenario Outline: An Ingress relays the status code returned by the backend service
+    (<description>)
+
+    Given the backend service responds with status-code <code>
+    When I send a "GET" request to "http://backend-response/status"
+    Then the response status-code returned for the backend status-code <code> is recorded
+
+    Examples:
+      | code | description           |
+      | 201  | Created               |
+      | 204  | No Content            |
+      | 400  | Bad Request           |
+      | 404  | Not Found             |
+      | 418  | I'm a teapot          |
+      | 500  | Internal Server Error |
+      | 502  | Bad Gateway           |
+      | 503  | Service Unavailable   |
+      | 504  | Gateway Timeout       |
+
+  Scenario: An Ingress relays the response headers returned by the backend service
+    Given the backend service adds the response header "X-Backend-Response" with value "passthrough"
+    And the backend service adds the response header "Cache-Control" with value "no-store"
+    When I send a "GET" request to "http://backend-response/headers"
+    Then the response status-code must be 200
+    And the response must be served by the "backend-response" service
+    And the response header "X-Backend-Response" returned by the backend service is recorded
+    And the response header "Cache-Control" returned by the backend service is recorded
@@ -0,0 +1,38 @@
+@sig-network @informational @release-1.19
+Feature: Backend TLS
+  A backend service may only accept HTTPS connections. The appProtocol field
+  of the service port, set to "https", indicates the ingress controller must
+  establish a TLS connection to the backend service (re-encryption).
+  
+  The Ingress specification does not define how the ingress controller
+  connects to the backend service. This feature does not assert a particular
+  behavior, it records if the controller re-encrypts the requests sent to a
+  backend service serving HTTPS, and the TLS connection received by the
+  backend service, in the report.
+
+  Background:
+    Given an Ingress resource with HTTPS backend services in a new random namespace
+    """
+    apiVersion: networking.k8s.io/v1
+    kind: Ingress
+    metadata:
+      name: backend-tls
+    spec:
+      rules:
+        - host: "backend-tls"
+          http:
+            paths:
+              - path: /
+                pathType: Prefix
+                backend:
+                  service:
+                    name: backend-tls
+                    port:
+                      number: 8443
+    """
+    Then The Ingress status shows the IP address or FQDN where it is exposed
+
+  Scenario: An Ingress with a backend service serving HTTPS
+    When I send a "GET" request to "http://backend-tls" that may fail
+    Then the connection to the backend service is recorded
+
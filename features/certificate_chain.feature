@@ -0,0 +1,83 @@
+@sig-network @release-1.19
+Feature: Certificate chain
+  The certificate stored in a TLS secret can be issued by an intermediate
+  certificate authority. The secret then contains the certificate followed by
+  the certificate of the intermediate authority. The ingress controller must
+  present the complete chain, so clients trusting the root certificate
+  authority can verify it.
+  
+  Certificates with Ed25519 keys are not supported by all the TLS
+  implementations. That scenario records the result in the report.
+
+  Background:
+    Given a new random namespace
+    Given a certificate authority with an intermediate certificate authority
+
+  @conformance
+  Scenario Outline: An Ingress presents the certificate chain issued by an intermediate certificate authority
+    (certificate with a <key> key)
+
+    Given a TLS secret named "<secret>" with a "<key>" key for the "<host>" hostname issued by the intermediate certificate authority
+    And an Ingress resource
+    """
+    apiVersion: networking.k8s.io/v1
+    kind: Ingress
+    metadata:
+      name: certificate-chain
+    spec:
+      tls:
+        - hosts:
+            - <host>
+          secretName: <secret>
+      rules:
+        - host: <host>
+          http:
+            paths:
+              - path: /
+                pathType: Prefix
+                backend:
+                  service:
+                    name: <service>
+                    port:
+                      number: 8080
+    """
+    Then The Ingress status shows the IP address or FQDN where it is exposed
+    When I send a "GET" request to "https://<host>"
+    Then the certificate chain presented by the server must be verified by the certificate authority for the "<host>" hostname
+    And the response status-code must be 200
+    And the response must be served by the "<service>" service
+
+    Examples:
+      | key   | host            | secret    | service         |
+      | RSA   | rsa.chain.com   | rsa-tls   | rsa-chain-com   |
+      | ECDSA | ecdsa.chain.com | ecdsa-tls | ecdsa-chain-com |
+
+  @informational
+  Scenario: An Ingress records the certificate chain presented for a certificate with an Ed25519 key
+    Given a TLS secret named "ed25519-tls" with a "Ed25519" key for the "ed25519.chain.com" hostname issued by the intermediate certificate authority
+    And an Ingress resource
+    """
+    apiVersion: networking.k8s.io/v1
+    kind: Ingress
+    metadata:
+      name: certificate-chain
+    spec:
+      tls:
+        - hosts:
+            - ed25519.chain.com
+          secretName: ed25519-tls
+      rules:
+        - host: ed25519.chain.com
+          http:
+            paths:
+              - path: /
+                pathType: Prefix
+                backend:
+                  service:
+                    name: ed25519-chain-com
+                    port:
+                      number: 8080
+    """
+    Then The Ingress status shows the IP address or FQDN where it is exposed
+    When I send a "GET" request to "https://ed25519.chain.com" that may fail
+    Then the verification of the certificate chain presented for the "ed25519.chain.com" hostname is recorded
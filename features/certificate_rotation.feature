@@ -0,0 +1,46 @@
+@sig-network @conformance @release-1.19
+Feature: Certificate rotation
+  The certificate stored in a TLS secret referenced by an Ingress can be
+  renewed updating the secret, without changes in the Ingress. The ingress
+  controller must present the renewed certificate.
+  
+  The maximum wait time for the renewed certificate is configured using the
+  flag --wait-time-for-certificate-rotation.
+
+  Background:
+    Given a new random namespace
+    Given a self-signed TLS secret named "rotation-tls" for the "rotation.tls.com" hostname
+    Given an Ingress resource
+    """
+    apiVersion: networking.k8s.io/v1
+    kind: Ingress
+    metadata:
+      name: certificate-rotation
+    spec:
+      tls:
+        - hosts:
+            - rotation.tls.com
+          secretName: rotation-tls
+      rules:
+        - host: rotation.tls.com
+          http:
+            paths:
+              - path: /
+                pathType: Prefix
+                backend:
+                  service:
+                    name: rotation-tls-com
+                    port:
+                      number: 8080
+    """
+    Then The Ingress status shows the IP address or FQDN where it is exposed
+
+  Scenario: An Ingress presents the renewed certificate after the TLS secret is updated
+    When I send a "GET" request to "https://rotation.tls.com"
+    Then the certificate presented by the server must be the one stored in the "rotation-tls" secret
+    When the certificate stored in the "rotation-tls" secret is renewed
+    Then the certificate presented for "https://rotation.tls.com" must be the one stored in the "rotation-tls" secret within the configured time
+    And the serial number of the certificate presented by the server must be different from the previous one
+    And the response status-code must be 200
+    And the response must be served by the "rotation-tls-com" service
+    And the time elapsed until the renewed certificate was presented is recorded
@@ -0,0 +1,148 @@
+@sig-network @conformance @release-1.19
+Feature: Default backend with rules
+  An Ingress may define a default backend in the field `defaultBackend`
+  together with host and path rules. The default backend handles the
+  requests that do not match any rule, including requests with a host
+  matching a rule but none of its paths.
+  
+  If no Ingress defines a default backend, the requests that do not match
+  any rule are handled by the ingress controller, returning a 404 status
+  code.
+
+  Background:
+    Given a new random namespace
+
+  Scenario Outline: An Ingress with rules and a default backend should send requests not matching any rule to the default backend
+    (request <host><path>)
+
+    Given an Ingress resource
+    """
+    apiVersion: networking.k8s.io/v1
+    kind: Ingress
+    metadata:
+      name: default-backend-rules
+    spec:
+      defaultBackend:
+        service:
+          name: default-backend
+          port:
+            number: 8080
+      rules:
+        - host: foo.bar.com
+          http:
+            paths:
+              - path: /app
+                pathType: Prefix
+                backend:
+                  service:
+                    name: foo-bar-com
+                    port:
+                      number: 8080
+
+        - http:
+            paths:
+              - path: /static
+                pathType: Exact
+                backend:
+                  service:
+                    name: static
+                    port:
+                      number: 8080
+    """
+    Then The Ingress status shows the IP address or FQDN where it is exposed
+    When I send a "GET" request to "http://<host><path>"
+    Then the response status-code must be 200
+    And the response must be served by the "<service>" service
+    And the request host must be "<host>"
+    And the request path must be "<path>"
+
+    Examples:
+      | host        | path       | service         |
+      | foo.bar.com | /app       | foo-bar-com     |
+      | foo.bar.com | /app/sub   | foo-bar-com     |
+      | foo.bar.com | /          | default-backend |
+      | foo.bar.com | /other     | default-backend |
+      | baz.bar.com | /static    | static          |
+      | baz.bar.com | /          | default-backend |
+      | baz.bar.com | /static/js | default-backend |
+
+  Scenario Outline: An Ingress with rules and without a default backend should return 404 for requests not matching any rule
+    (request <host><path>)
+
+    Given an Ingress resource
+    """
+    apiVersion: networking.k8s.io/v1
+    kind: Ingress
+    metadata:
+      name: no-default-backend
+    spec:
+      rules:
+        - host: foo.bar.com
+          http:
+            paths:
+              - path: /app
+                pathType: Prefix
+                backend:
+                  service:
+                    name: foo-bar-com
+                    port:
+                      number: 8080
+
+        - http:
+            paths:
+              - path: /static
+                pathType: Exact
+                backend:
+                  service:
+                    name: static
+                    port:
+                      number: 8080
+    """
+    Then The Ingress status shows the IP address or FQDN where it is exposed
+    When I send a "GET" request to "http://<host><path>"
+    Then the response status-code must be 404
+
+    Examples:
+      | host        | path       |
+      | foo.bar.com | /          |
+      | foo.bar.com | /other     |
+      | baz.bar.com | /          |
+      | baz.bar.com | /static/js |
+
+  Scenario: An Ingress with rules and without a default backend should send requests matching a rule to its backend service
+    Given an Ingress resource
+    """
+    apiVersion: networking.k8s.io/v1
+    kind: Ingress
+    metadata:
+      name: no-default-backend
+    spec:
+      rules:
+        - host: foo.bar.com
+          http:
+            paths:
+              - path: /app
+                pathType: Prefix
+                backend:
+                  service:
+                    name: foo-bar-com
+                    port:
+                      number: 8080
+
+        - http:
+            paths:
+              - path: /static
+                pathType: Exact
+                backend:
+                  service:
+                    name: static
+                    port:
+                      number: 8080
+    """
+    Then The Ingress status shows the IP address or FQDN where it is exposed
+    When I send a "GET" request to "http://foo.bar.com/app"
+    Then the response status-code must be 200
+    And the response must be served by the "foo-bar-com" service
+    When I send a "GET" request to "http://baz.bar.com/static"
+    Then the response status-code must be 200
+    And the response must be served by the "static" service
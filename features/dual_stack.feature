@@ -0,0 +1,48 @@
+@sig-network @conformance @release-1.19 @dual-stack
+Feature: Dual-stack
+  An ingress controller running in a dual-stack cluster may expose an
+  Ingress using both IPv4 and IPv6 addresses. Requests sent to an address
+  of each family must be handled in the same way.
+  
+  The Ingress specification does not require dual-stack support, so the
+  feature only runs using --address-family=dual. Each scenario records the
+  IP families reported in the Ingress status in the report. Every request
+  is sent using an address of each family and the responses must match.
+
+  Background:
+    Given an Ingress resource in a new random namespace
+    """
+    apiVersion: networking.k8s.io/v1
+    kind: Ingress
+    metadata:
+      name: dual-stack
+    spec:
+      rules:
+        - host: "dual-stack"
+          http:
+            paths:
+              - path: /
+                pathType: Prefix
+                backend:
+                  service:
+                    name: dual-stack
+                    port:
+                      number: 8080
+    """
+    Then The Ingress status shows the IP address or FQDN where it is exposed
+    And the IP families in the Ingress status are recorded
+
+  Scenario Outline: An Ingress should handle requests sent using both IP families in the same way
+    (<method> request to <path>)
+
+    When I send a "<method>" request to "http://dual-stack<path>" using each IP family in the Ingress status
+    Then the response status-code must be 200 for each IP family
+    And the response must be served by the "dual-stack" service for each IP family
+    And the request received by the backend service must be the same for each IP family
+
+    Examples:
+      | method | path          |
+      | GET    | /             |
+      | GET    | /sub-path     |
+      | POST   | /resource     |
+      | GET    | /query?x=1&y= |
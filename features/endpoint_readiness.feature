@@ -0,0 +1,37 @@
+@sig-network @conformance @release-1.19 @unreleased-echoserver
+Feature: Endpoint readiness
+  Pods of a backend service that fail the readiness probe are removed from
+  the ready endpoints of the service. The ingress controller must stop
+  sending requests to these pods.
+  
+  The echoserver readiness is changed using the /admin/readiness endpoint.
+
+  Background:
+    Given an Ingress resource in a new random namespace
+    """
+    apiVersion: networking.k8s.io/v1
+    kind: Ingress
+    metadata:
+      name: endpoint-readiness
+    spec:
+      rules:
+        - host: "endpoint-readiness"
+          http:
+            paths:
+              - path: /
+                pathType: Prefix
+                backend:
+                  service:
+                    name: endpoint-readiness
+                    port:
+                      number: 8080
+    """
+    Then The Ingress status shows the IP address or FQDN where it is exposed
+
+  Scenario: An Ingress stops sending requests to pods that are not ready
+    Given the "endpoint-readiness" service has 2 ready endpoints
+    When the pod serving "http://endpoint-readiness/admin/readiness" reports it is not ready
+    Then the "endpoint-readiness" service must have 1 ready endpoints
+    When I send 20 requests to "http://endpoint-readiness/not-ready"
+    Then all the responses status-code must be 200
+    And none of the responses must be served by the pod that is not ready
@@ -0,0 +1,53 @@
+@sig-network @informational @release-1.19 @unreleased-echoserver
+Feature: Forwarded headers
+  Ingress controllers usually add headers to the requests sent to the backend
+  service with information about the original request, like the client address
+  or the protocol used by the client.
+  
+  The Ingress specification does not define these headers. This feature records the
+  X-Forwarded-For, X-Forwarded-Proto, X-Forwarded-Host, Forwarded and X-Real-IP
+  request headers, and the source address observed by the backend service.
+  
+  When present, the protocol of the forwarded headers must match the protocol used by the client.
+
+  Background:
+    Given a new random namespace
+    Given a self-signed TLS secret named "conformance-tls" for the "forwarded.headers.com" hostname
+    Given an Ingress resource
+    """
+    apiVersion: networking.k8s.io/v1
+    kind: Ingress
+    metadata:
+      name: forwarded-headers
+    spec:
+      tls:
+        - hosts:
+            - forwarded.headers.com
+          secretName: conformance-tls
+      rules:
+        - host: forwarded.headers.com
+          http:
+            paths:
+              - path: /
+                pathType: Prefix
+                backend:
+                  service:
+                    name: forwarded-headers
+                    port:
+                      number: 8080
+    """
+    Then The Ingress status shows the IP address or FQDN where it is exposed
+
+  Scenario Outline: An Ingress records the forwarded headers sent to the backend service
+    (request using <scheme>)
+
+    When I send a "GET" request to "<scheme>://forwarded.headers.com"
+    Then the response status-code must be 200
+    And the response must be served by the "forwarded-headers" service
+    And the forwarded headers received by the backend service using "<scheme>" are recorded
+    And the forwarded protocol must be "<scheme>" when present
+
+    Examples:
+      | scheme |
+      | http   |
+      | https  |
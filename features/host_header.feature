@@ -0,0 +1,88 @@
+@sig-network @release-1.19
+Feature: Host header matching
+  The host of an Ingress rule is matched against the host of the HTTP
+  request. DNS names are case insensitive and the port of the Host header
+  is ignored, so a request for FOO.bar.com or foo.bar.com:80 matches the
+  host foo.bar.com. The Host header received by the backend service must
+  not be modified.
+  
+  A rule without host matches any request that does not match the host of
+  another rule, including requests using an IP address as host.
+  
+  A fully qualified name with a trailing dot (foo.bar.com.) refers to the
+  same host, but the Ingress specification does not define if it must match
+  the host of a rule. That scenario only records how the ingress controller
+  handles the request in the report.
+
+  Background:
+    Given an Ingress resource in a new random namespace
+    """
+    apiVersion: networking.k8s.io/v1
+    kind: Ingress
+    metadata:
+      name: host-header
+    spec:
+      rules:
+        - host: foo.bar.com
+          http:
+            paths:
+              - path: /
+                pathType: Prefix
+                backend:
+                  service:
+                    name: foo-bar-com
+                    port:
+                      number: 8080
+
+        - http:
+            paths:
+              - path: /
+                pathType: Prefix
+                backend:
+                  service:
+                    name: no-host
+                    port:
+                      number: 8080
+    """
+    Then The Ingress status shows the IP address or FQDN where it is exposed
+
+  @conformance
+  Scenario Outline: An Ingress with a host rule should send traffic to the matching backend service
+    (host foo.bar.com matches request <host>)
+
+    When I send a "GET" request to "http://<host>"
+    Then the response status-code must be 200
+    And the response must be served by the "foo-bar-com" service
+    And the request host must be "<host>"
+
+    Examples:
+      | host           |
+      | foo.bar.com    |
+      | FOO.bar.com    |
+      | Foo.Bar.Com    |
+      | foo.bar.com:80 |
+
+  @conformance
+  Scenario Outline: An Ingress with a rule without host should send traffic not matching other hosts to its backend service
+    (rule without host matches request <host>)
+
+    When I send a "GET" request to "http://<host>"
+    Then the response status-code must be 200
+    And the response must be served by the "no-host" service
+    And the request host must be "<host>"
+
+    Examples:
+      | host              |
+      | bar.bar.com       |
+      | foo.bar.com.br    |
+      | 192.0.2.10        |
+      | 192.0.2.10:80     |
+      | [2001:db8::10]    |
+      | [2001:db8::10]:80 |
+
+  @informational
+  Scenario: An Ingress records how a host with a trailing dot is matched
+    (host foo.bar.com and request foo.bar.com.)
+
+    When I send a "GET" request to "http://foo.bar.com." that may fail
+    Then the service and host received by the backend service are recorded
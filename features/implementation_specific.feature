@@ -0,0 +1,80 @@
+@sig-network @informational @release-1.19
+Feature: ImplementationSpecific path type
+  An Ingress may define path rules with pathType ImplementationSpecific.
+  With this path type, matching is up to the IngressClass. Implementations
+  can treat this as a separate pathType or treat it identically to Prefix
+  or Exact path types.
+  
+  https://kubernetes.io/docs/concepts/services-networking/ingress/#path-types
+  
+  The Ingress specification does not define the semantics of this path type.
+  This feature does not assert a particular behavior, it records how
+  the controller handles each request in the report.
+
+  Background:
+    Given an Ingress resource in a new random namespace
+    """
+    apiVersion: networking.k8s.io/v1
+    kind: Ingress
+    metadata:
+      name: implementation-specific-path-rules
+    spec:
+      rules:
+        - host: "implementation-specific"
+          http:
+            paths:
+              - path: /foo
+                pathType: ImplementationSpecific
+                backend:
+                  service:
+                    name: foo-implementation-specific
+                    port:
+                      number: 8080
+    
+              - path: /bar/
+                pathType: ImplementationSpecific
+                backend:
+                  service:
+                    name: bar-slash-implementation-specific
+                    port:
+                      number: 8080
+    
+              - path: /regex/[a-z]+
+                pathType: ImplementationSpecific
+                backend:
+                  service:
+                    name: regex-implementation-specific
+                    port:
+                      number: 8080
+    
+              - path: /glob/*
+                pathType: ImplementationSpecific
+                backend:
+                  service:
+                    name: glob-implementation-specific
+                    port:
+                      number: 8080
+    """
+    Then The Ingress status shows the IP address or FQDN where it is exposed
+
+  Scenario Outline: An Ingress with ImplementationSpecific path rules records how requests are matched
+    (<description>)
+
+    When I send a "GET" request to "http://implementation-specific<path>"
+    Then the response status-code and the service serving the request for "<path>" are recorded
+
+    Examples:
+      | path          | description                                           |
+      | /foo          | path /foo with request /foo                           |
+      | /foo/         | path /foo with request with trailing slash /foo/      |
+      | /FOO          | path /foo with case variant request /FOO              |
+      | /foo/bar      | path /foo with sub-path request /foo/bar              |
+      | /foobar       | path /foo with string prefix request /foobar          |
+      | /bar          | path /bar/ with request without trailing slash /bar   |
+      | /bar/         | path /bar/ with request /bar/                         |
+      | /bar/baz      | path /bar/ with sub-path request /bar/baz             |
+      | /regex/abc    | regex-like path /regex/[a-z]+ with request /regex/abc |
+      | /regex/123    | regex-like path /regex/[a-z]+ with request /regex/123 |
+      | /regex/[a-z]+ | regex-like path /regex/[a-z]+ with literal request    |
+      | /glob/abc     | glob-like path /glob/* with request /glob/abc         |
+      | /glob/*       | glob-like path /glob/* with literal request           |
@@ -0,0 +1,85 @@
+@sig-network @release-1.19
+Feature: Ingress status
+  The ingress controller reports the addresses where an Ingress is exposed
+  in the field status.loadBalancer.ingress. Each entry contains a valid IP
+  address or DNS name, and optionally the ports where the Ingress is exposed.
+  
+  Every address in the status must serve the traffic of the Ingress and the
+  addresses must not change when the Ingress is updated. When an entry
+  reports ports, the requests are sent to each TCP port, using HTTPS for the
+  port 443. Sending all the requests to the same address (flags
+  --ingress-http-address and --ingress-https-address) cannot verify every
+  address, so the scenario fails. Use --ingress-address-map instead.
+  
+  Ingresses of the same class are exposed by the same ingress controller,
+  so they must report the same addresses.
+
+  Background:
+    Given a new random namespace
+    Given a self-signed TLS secret named "ingress-status-tls" for the "ingress-status" hostname
+    Given an Ingress resource
+    """
+    apiVersion: networking.k8s.io/v1
+    kind: Ingress
+    metadata:
+      name: ingress-status
+    spec:
+      tls:
+        - hosts:
+            - ingress-status
+          secretName: ingress-status-tls
+      rules:
+        - host: "ingress-status"
+          http:
+            paths:
+              - path: /
+                pathType: Prefix
+                backend:
+                  service:
+                    name: ingress-status
+                    port:
+                      number: 8080
+    """
+    Then The Ingress status shows the IP address or FQDN where it is exposed
+
+  @conformance
+  Scenario: An Ingress status should contain valid addresses
+    Then each address in the Ingress status must be a valid IP address or DNS name
+    And the ports in the Ingress status must be valid when present
+
+  @conformance
+  Scenario: An Ingress should serve traffic on every address in its status
+    When I send a "GET" request to "http://ingress-status" using every address in the Ingress status
+    Then all the responses status-code must be 200
+    And all the responses must be served by the "ingress-status" service
+
+  @conformance
+  Scenario: An Ingress status should not change when the Ingress is updated
+    When the Ingress is updated adding the path "/updated"
+    Then the addresses in the Ingress status must not change
+    When I send a "GET" request to "http://ingress-status/updated"
+    Then the response status-code must be 200
+    And the response must be served by the "ingress-status" service
+
+  @conformance
+  Scenario: Ingresses of the same class should report the same addresses
+    Given another Ingress resource
+    """
+    apiVersion: networking.k8s.io/v1
+    kind: Ingress
+    metadata:
+      name: ingress-status-same-class
+    spec:
+      rules:
+        - host: "ingress-status-same-class"
+          http:
+            paths:
+              - path: /
+                pathType: Prefix
+                backend:
+                  service:
+                    name: ingress-status-same-class
+                    port:
+                      number: 8080
+    """
+    Then the addresses in the status of both Ingresses must be the same
@@ -0,0 +1,67 @@
+@sig-network @informational @release-1.19
+Feature: Invalid TLS secrets
+  An Ingress may reference in spec.tls a secret that does not exist, a secret
+  that is not of type kubernetes.io/tls, a secret with a malformed certificate
+  or a secret with an expired certificate.
+  
+  The Ingress specification does not define how these secrets must be
+  handled. This feature records, for each kind of invalid secret, whether
+  other hosts of the same Ingress keep working and the certificate presented
+  for the host using the invalid secret (usually a default certificate of the
+  ingress controller).
+
+  Scenario Outline: An Ingress referencing a <variant> TLS secret
+    Given a new random namespace
+    Given a self-signed TLS secret named "valid-tls" for the "valid.secrets.com" hostname
+    Given a "<variant>" TLS secret named "invalid-tls" for the "invalid.secrets.com" hostname
+    Given an Ingress resource
+    """
+    apiVersion: networking.k8s.io/v1
+    kind: Ingress
+    metadata:
+      name: invalid-tls-secrets
+    spec:
+      tls:
+        - hosts:
+            - valid.secrets.com
+          secretName: valid-tls
+        - hosts:
+            - invalid.secrets.com
+          secretName: invalid-tls
+      rules:
+        - host: valid.secrets.com
+          http:
+            paths:
+              - path: /
+                pathType: Prefix
+                backend:
+                  service:
+                    name: valid-secrets-com
+                    port:
+                      number: 8080
+    
+        - host: invalid.secrets.com
+          http:
+            paths:
+              - path: /
+                pathType: Prefix
+                backend:
+                  service:
+                    name: invalid-secrets-com
+                    port:
+                      number: 8080
+    """
+    Then The Ingress status shows the IP address or FQDN where it is exposed
+    When I send a "GET" request to "https://valid.secrets.com" that may fail
+    Then the response for the "valid.secrets.com" host with a "<variant>" secret in the Ingress is recorded
+    When I send a "GET" request to "https://invalid.secrets.com" that may fail
+    Then the response for the "invalid.secrets.com" host with a "<variant>" secret in the Ingress is recorded
+    When I send a "GET" request to "http://invalid.secrets.com" that may fail
+    Then the response for the "invalid.secrets.com" host over HTTP with a "<variant>" secret in the Ingress is recorded
+
+    Examples:
+      | variant    |
+      | missing    |
+      | wrong-type |
+      | malformed  |
+      | expired    |
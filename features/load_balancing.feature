@@ -1,6 +1,11 @@
-@sig-network @conformance @release-1.19
+@sig-network @release-1.19
 Feature: Load Balancing
   An Ingress exposing a backend service with multiple replicas should use all the pods available
+  and distribute the requests evenly between them. The maximum ratio between the pods serving
+  the most and the fewest requests is configured using the flag --max-load-distribution-ratio.
+  Ingress controllers may use load balancing algorithms that take into account the latency or
+  the load of each pod, so the result of a chi-square uniformity test is only recorded, using the
+  significance level configured with the flag --load-distribution-significance.
   The feature sessionAffinity is not configured in the backend service https://kubernetes.io/docs/reference/generated/kubernetes-api/v1.19/#service-v1-core
 
   Background:
@@ -16,6 +21,20 @@ Feature: Load Balancing
     Then The Ingress status shows the IP address or FQDN where it is exposed
     Then The backend deployment "echo-service" for the ingress resource is scaled to 10
 
+  @conformance
   Scenario Outline: An Ingress with no rules should send all requests to the default backend and
     When I send 100 requests to "http://load-balancing"
     Then all the responses status-code must be 200 and the response body should contain the IP address of 10 different Kubernetes pods
+
+  @conformance
+  Scenario: An Ingress distributes the requests evenly between the pods of the backend service
+    When I send 1000 requests to "http://load-balancing"
+    Then all the responses status-code must be 200 and the response body should contain the IP address of 10 different Kubernetes pods
+    And the number of requests served by each pod is recorded
+    And the ratio between the pods serving the most and the fewest requests must not exceed the configured maximum
+
+  @informational
+  Scenario: An Ingress may distribute the requests uniformly between the pods of the backend service
+    When I send 1000 requests to "http://load-balancing"
+    Then the number of requests served by each pod is recorded
+    And the result of the chi-square uniformity test is recorded
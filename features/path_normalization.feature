@@ -0,0 +1,112 @@
+@sig-network @release-1.19 @unreleased-echoserver
+Feature: Path normalization
+  An Ingress may define routing rules based on the request path.
+  
+  The request-target sent by a client may contain percent-encoded characters,
+  dot-segments, empty segments or a query string. The Ingress specification
+  does not define how such paths are normalized before matching, or if the
+  request-target is modified before it is forwarded to the backend service.
+  
+  Requests in this feature are sent using the request-target exactly as written.
+
+  Background:
+    Given an Ingress resource in a new random namespace
+    """
+    apiVersion: networking.k8s.io/v1
+    kind: Ingress
+    metadata:
+      name: path-normalization
+    spec:
+      rules:
+        - host: "exact-path-normalization"
+          http:
+            paths:
+              - path: /foo
+                pathType: Exact
+                backend:
+                  service:
+                    name: foo-exact
+                    port:
+                      number: 8080
+    
+              - path: /foo/bar
+                pathType: Exact
+                backend:
+                  service:
+                    name: foo-bar-exact
+                    port:
+                      number: 8080
+    
+        - host: "prefix-path-normalization"
+          http:
+            paths:
+              - path: /foo
+                pathType: Prefix
+                backend:
+                  service:
+                    name: foo-prefix
+                    port:
+                      number: 8080
+    
+              - path: /foo/bar
+                pathType: Prefix
+                backend:
+                  service:
+                    name: foo-bar-prefix
+                    port:
+                      number: 8080
+    """
+    Then The Ingress status shows the IP address or FQDN where it is exposed
+
+  @conformance
+  Scenario: An Ingress with exact path rules should ignore the query string when matching
+    (exact /foo matches request /foo?bar=baz)
+
+    When I send a "GET" request with the raw request-target "/foo?bar=baz" to "http://exact-path-normalization"
+    Then the response status-code must be 200
+    And the response must be served by the "foo-exact" service
+    And the request-target must be "/foo?bar=baz"
+
+  @conformance
+  Scenario: An Ingress with prefix path rules should ignore the query string when matching
+    (prefix /foo matches request /foo?path=/foo/bar, query string contains a longer path)
+
+    When I send a "GET" request with the raw request-target "/foo?path=/foo/bar" to "http://prefix-path-normalization"
+    Then the response status-code must be 200
+    And the response must be served by the "foo-prefix" service
+    And the request-target must be "/foo?path=/foo/bar"
+
+  @informational
+  Scenario Outline: An Ingress with path rules records how non-normalized request-targets are matched and forwarded
+    (<description>)
+
+    When I send a "GET" request with the raw request-target "<target>" to "http://<host>"
+    Then the handling of the "<target>" request-target by "<host>" is recorded
+
+    Examples:
+      | host                      | target              | description                                                |
+      | exact-path-normalization  | /foo%2Fbar          | percent-encoded slash                                      |
+      | exact-path-normalization  | /foo%2fbar          | lowercase percent-encoded slash                            |
+      | exact-path-normalization  | /%66oo              | percent-encoded unreserved character                       |
+      | exact-path-normalization  | /foo/%62ar          | percent-encoded unreserved character in the second segment |
+      | exact-path-normalization  | //foo               | leading double slash                                       |
+      | exact-path-normalization  | /foo//bar           | double slash between segments                              |
+      | exact-path-normalization  | /foo/./bar          | single dot-segment                                         |
+      | exact-path-normalization  | /baz/../foo         | double dot-segment                                         |
+      | exact-path-normalization  | /foo/bar/..         | trailing double dot-segment                                |
+      | exact-path-normalization  | /foo/%2e%2e/foo     | percent-encoded double dot-segment                         |
+      | exact-path-normalization  | /../foo             | double dot-segment above the root                          |
+      | exact-path-normalization  | /foo?bar=%2F        | percent-encoded slash in the query string                  |
+      | exact-path-normalization  | /foo/bar?           | empty query string                                         |
+      | prefix-path-normalization | /foo%2Fbar          | percent-encoded slash                                      |
+      | prefix-path-normalization | /foo%2fbar          | lowercase percent-encoded slash                            |
+      | prefix-path-normalization | /%66oo/bar          | percent-encoded unreserved character                       |
+      | prefix-path-normalization | //foo/bar           | leading double slash                                       |
+      | prefix-path-normalization | /foo//bar           | double slash between segments                              |
+      | prefix-path-normalization | /foo/bar//          | trailing double slash                                      |
+      | prefix-path-normalization | /foo/./bar          | single dot-segment                                         |
+      | prefix-path-normalization | /baz/../foo/bar     | double dot-segment                                         |
+      | prefix-path-normalization | /foo/bar/..         | trailing double dot-segment                                |
+      | prefix-path-normalization | /foo/%2e%2e/foo/bar | percent-encoded double dot-segment                         |
+      | prefix-path-normalization | /foo/bar?x=y&x=z    | repeated query string keys                                 |
+      | prefix-path-normalization | /foo;bar            | path parameter                                             |
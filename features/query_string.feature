@@ -0,0 +1,100 @@
+@sig-network @conformance @release-1.19 @unreleased-echoserver
+Feature: Query string
+  An Ingress may define routing rules based on the request path.
+  
+  The query string is not part of the path used to match rules.
+  It must be forwarded to the backend service without modifications,
+  preserving the order of the parameters, repeated keys, empty values
+  and percent-encoded characters.
+
+  Background:
+    Given an Ingress resource in a new random namespace
+    """
+    apiVersion: networking.k8s.io/v1
+    kind: Ingress
+    metadata:
+      name: query-string
+    spec:
+      rules:
+        - host: "query-string"
+          http:
+            paths:
+              - path: /query
+                pathType: Exact
+                backend:
+                  service:
+                    name: query-exact
+                    port:
+                      number: 8080
+    
+              - path: /prefix
+                pathType: Prefix
+                backend:
+                  service:
+                    name: prefix
+                    port:
+                      number: 8080
+    
+              - path: /prefix/sub
+                pathType: Prefix
+                backend:
+                  service:
+                    name: prefix-sub
+                    port:
+                      number: 8080
+    """
+    Then The Ingress status shows the IP address or FQDN where it is exposed
+
+  Scenario Outline: An Ingress should send the query string to the backend service without modifications
+    (<description>)
+
+    When I send a "GET" request to "http://query-string/query?<query>"
+    Then the response status-code must be 200
+    And the response must be served by the "query-exact" service
+    And the request path must be "/query"
+    And the request query string must be "<query>"
+
+    Examples:
+      | query              | description                                             |
+      | b=2&a=1            | parameters are not sorted                               |
+      | a=1&a=2&a=1        | repeated keys are preserved                             |
+      | a=&b               | empty values are preserved                              |
+      | a=1&&b=2           | empty parameters are preserved                          |
+      | a=%20b%2Fc%3Fd%23e | percent-encoded characters are not decoded              |
+      | a=%2f%3f           | lowercase percent-encoded characters are not normalized |
+      | a=b+c              | plus signs are not decoded                              |
+      | a=%E2%9C%93        | percent-encoded UTF-8 characters are not decoded        |
+      | a=/prefix/sub      | slashes are preserved                                   |
+
+  Scenario: An Ingress with exact path rules should ignore the query string when matching
+    (exact /query matches request /query?path=/prefix)
+
+    When I send a "GET" request to "http://query-string/query?path=/prefix"
+    Then the response status-code must be 200
+    And the response must be served by the "query-exact" service
+    And the request path must be "/query"
+    And the request query string must be "path=/prefix"
+
+  Scenario: An Ingress with prefix path rules should ignore the query string when matching the longest path
+    (prefix /prefix matches request /prefix?next=/prefix/sub)
+
+    When I send a "GET" request to "http://query-string/prefix?next=/prefix/sub"
+    Then the response status-code must be 200
+    And the response must be served by the "prefix" service
+    And the request path must be "/prefix"
+    And the request query string must be "next=/prefix/sub"
+
+  Scenario: An Ingress with exact path rules should not match when the query string is part of the path
+    (exact /query does not match request /query%3Fa=1)
+
+    When I send a "GET" request to "http://query-string/query%3Fa=1"
+    Then the response status-code must be 404
+
+  Scenario: An Ingress should not send the fragment to the backend service
+    (fragments are not part of the request-target)
+
+    When I send a "GET" request to "http://query-string/query?a=1#fragment"
+    Then the response status-code must be 200
+    And the response must be served by the "query-exact" service
+    And the request path must be "/query"
+    And the request query string must be "a=1"
@@ -0,0 +1,67 @@
+@sig-network @release-1.19 @unreleased-echoserver
+Feature: Request body
+  An Ingress forwards the body of the requests to the backend service.
+  
+  The body must arrive at the backend service without modifications,
+  independently of the size of the body or how it is transferred:
+  using a Content-Length header, chunked transfer encoding, or
+  waiting for a 100 Continue response (Expect: 100-continue).
+
+  Ingress controllers usually limit the size of the request body (for
+  example, 1 MiB by default in ingress-nginx). The Ingress specification
+  does not define such limit, so the response to the requests with bodies
+  of 1 MiB or more is recorded. When the ingress controller accepts the
+  request (2xx response), the body must arrive without modifications.
+
+  Background:
+    Given a new random namespace
+    Given an Ingress resource named "request-body" with this spec:
+    """
+    defaultBackend:
+      service:
+        name: echo-service
+        port:
+          number: 8080
+    """
+    Then The Ingress status shows the IP address or FQDN where it is exposed
+
+  @conformance
+  Scenario Outline: An Ingress should send the request body to the backend service without modifications
+    When I send a "<method>" request to "http://request-body/upload" with a body of <size> bytes using "<transfer>" transfer
+    Then the response status-code must be 200
+    And the response must be served by the "echo-service" service
+    And the request method must be "<method>"
+    And the request body must be received without modifications
+
+    Examples:
+      | method | size  | transfer       |
+      | POST   | 0     | content-length |
+      | POST   | 1     | content-length |
+      | POST   | 1024  | content-length |
+      | POST   | 65536 | content-length |
+      | PUT    | 1024  | content-length |
+      | PATCH  | 1024  | content-length |
+      | POST   | 0     | chunked        |
+      | POST   | 1024  | chunked        |
+      | PUT    | 65536 | chunked        |
+      | POST   | 1024  | 100-continue   |
+      | PUT    | 65536 | 100-continue   |
+
+  @conformance
+  Scenario Outline: An Ingress may limit the size of the request body but should send the accepted bodies without modifications
+    When I send a "<method>" request to "http://request-body/upload" with a body of <size> bytes using "<transfer>" transfer that may fail
+    Then the response to the request with a large body is recorded
+    And the request body must be received without modifications when the request is accepted
+
+    Examples:
+      | method | size     | transfer       |
+      | POST   | 1048576  | content-length |
+      | POST   | 10485760 | content-length |
+      | POST   | 33554432 | content-length |
+      | PUT    | 10485760 | content-length |
+      | POST   | 1048576  | chunked        |
+      | POST   | 33554432 | chunked        |
+      | PUT    | 10485760 | chunked        |
+      | POST   | 1048576  | 100-continue   |
+      | POST   | 33554432 | 100-continue   |
+      | PUT    | 10485760 | 100-continue   |
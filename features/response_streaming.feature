@@ -0,0 +1,67 @@
+@sig-network @release-1.19 @unreleased-echoserver
+Feature: Response streaming
+  Backend services may send the response body in pieces over time,
+  like server-sent events or long-polling endpoints.
+  
+  An Ingress must send each piece of the response to the client as it is
+  received from the backend service, without buffering the complete response.
+  The pieces may arrive closer together than they were sent by up to the
+  tolerance configured with --stream-timing-tolerance.
+  
+  The time elapsed between the first and the last piece of the stream is
+  also recorded in the report.
+
+  Background:
+    Given a new random namespace
+    Given a self-signed TLS secret named "conformance-tls" for the "response.streaming.com" hostname
+    Given an Ingress resource
+    """
+    apiVersion: networking.k8s.io/v1
+    kind: Ingress
+    metadata:
+      name: response-streaming
+    spec:
+      tls:
+        - hosts:
+            - response.streaming.com
+          secretName: conformance-tls
+      rules:
+        - host: response.streaming.com
+          http:
+            paths:
+              - path: /admin/stream
+                pathType: Exact
+                backend:
+                  service:
+                    name: response-streaming
+                    port:
+                      number: 8080
+    """
+    Then The Ingress status shows the IP address or FQDN where it is exposed
+
+  @conformance
+  Scenario Outline: An Ingress should not buffer streamed responses
+    (request using <scheme>)
+
+    When I request a stream of 5 events sent every 1000 milliseconds from "<scheme>://response.streaming.com/admin/stream"
+    Then the stream status-code must be 200
+    And the stream must contain 5 events
+    And the first event must be received at least 4000 milliseconds before the stream finishes
+
+    Examples:
+      | scheme |
+      | http   |
+      | https  |
+
+  @informational
+  Scenario Outline: An Ingress records the time elapsed receiving streamed responses
+    (request using <scheme>)
+
+    When I request a stream of 5 events sent every 1000 milliseconds from "<scheme>://response.streaming.com/admin/stream"
+    Then the stream status-code must be 200
+    And the time elapsed between the first and the last piece of the stream is recorded
+
+    Examples:
+      | scheme |
+      | http   |
+      | https  |
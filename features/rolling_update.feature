@@ -0,0 +1,45 @@
+@sig-network @conformance @release-1.19 @unreleased-echoserver
+Feature: Rolling update
+  Pods of a backend service are replaced during a rolling update of the
+  Deployment. The ingress controller should stop sending requests to the
+  pods being terminated and start sending requests to the new pods once they
+  are ready, without failing the requests of the clients.
+  
+  The echoserver keeps serving requests during a drain period after it
+  receives the SIGTERM signal, giving time to the ingress controller to
+  remove the pod from the endpoints of the service.
+  
+  The percentage of failed requests during the rolling update must not
+  exceed the maximum configured using the flag --max-load-error-rate
+  (1% by default). The results of the requests are also recorded in the
+  report.
+
+  Background:
+    Given an Ingress resource in a new random namespace
+    """
+    apiVersion: networking.k8s.io/v1
+    kind: Ingress
+    metadata:
+      name: rolling-update
+    spec:
+      rules:
+        - host: "rolling-update"
+          http:
+            paths:
+              - path: /
+                pathType: Prefix
+                backend:
+                  service:
+                    name: rolling-update
+                    port:
+                      number: 8080
+    """
+    Then The Ingress status shows the IP address or FQDN where it is exposed
+
+  Scenario: An Ingress keeps serving requests during a rolling update of the backend service
+    Given the "rolling-update" service has 3 ready endpoints
+    When I start sending "GET" requests to "http://rolling-update/load" using 4 concurrent clients
+    And a rolling update of the "rolling-update" service is performed
+    And I stop sending requests
+    Then the results of the requests sent during the rolling update are recorded
+    And the error rate of the requests must not exceed the configured maximum
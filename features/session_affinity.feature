@@ -0,0 +1,42 @@
+@sig-network @informational @release-1.19
+Feature: Session affinity
+  A Service can define sessionAffinity ClientIP to send all the requests
+  from the same client to the same pod. Ingress controllers sending requests
+  directly to the endpoints of the service may not honor this setting.
+  
+  https://kubernetes.io/docs/concepts/services-networking/service/#proxy-mode-userspace
+  
+  The Ingress specification does not define how the session affinity of the
+  backend services must be handled. This feature does not assert a particular
+  behavior, it records the pods serving the requests sent by the same client.
+
+  Scenario Outline: An Ingress with a backend service using session affinity <affinity>
+    Given an Ingress resource in a new random namespace with backend services using "<affinity>" session affinity
+    """
+    apiVersion: networking.k8s.io/v1
+    kind: Ingress
+    metadata:
+      name: session-affinity
+    spec:
+      rules:
+        - host: "session-affinity"
+          http:
+            paths:
+              - path: /
+                pathType: Prefix
+                backend:
+                  service:
+                    name: session-affinity
+                    port:
+                      number: 8080
+    """
+    And The Ingress status shows the IP address or FQDN where it is exposed
+    And the "session-affinity" service has 5 ready endpoints
+    When I send 50 requests to "http://session-affinity/<affinity>"
+    Then all the responses status-code must be 200
+    And the pods serving the requests with "<affinity>" session affinity are recorded
+
+    Examples:
+      | affinity |
+      | None     |
+      | ClientIP |
@@ -0,0 +1,118 @@
+@sig-network @release-1.19
+Feature: TLS
+  An Ingress may define multiple entries in spec.tls, each one with a list of
+  hosts and the secret containing the certificate for those hosts. The
+  ingress controller must use the server name indication (SNI) sent by the
+  client to select the certificate of the matching entry.
+  
+  https://kubernetes.io/docs/concepts/services-networking/ingress/#tls
+  
+  The certificate presented for a server name not included in any entry is not
+  defined by the Ingress specification. That scenario records the certificate
+  presented by the ingress controller in the report.
+
+  Background:
+    Given a new random namespace
+    Given a self-signed TLS secret named "foo-tls" for the "foo.tls.com" hostnames
+    Given a self-signed TLS secret named "bar-tls" for the "bar.tls.com,baz.tls.com" hostnames
+    Given a self-signed TLS secret named "baz-tls" for the "baz.tls.com" hostnames
+    Given a self-signed TLS secret named "wildcard-tls" for the "*.wildcard.tls.com" hostnames
+    Given an Ingress resource
+    """
+    apiVersion: networking.k8s.io/v1
+    kind: Ingress
+    metadata:
+      name: tls
+    spec:
+      tls:
+        - hosts:
+            - foo.tls.com
+          secretName: foo-tls
+        - hosts:
+            - bar.tls.com
+          secretName: bar-tls
+        - hosts:
+            - baz.tls.com
+          secretName: baz-tls
+        - hosts:
+            - "*.wildcard.tls.com"
+          secretName: wildcard-tls
+      rules:
+        - host: foo.tls.com
+          http:
+            paths:
+              - path: /
+                pathType: Prefix
+                backend:
+                  service:
+                    name: foo-tls-com
+                    port:
+                      number: 8080
+    
+        - host: bar.tls.com
+          http:
+            paths:
+              - path: /
+                pathType: Prefix
+                backend:
+                  service:
+                    name: bar-tls-com
+                    port:
+                      number: 8080
+    
+        - host: baz.tls.com
+          http:
+            paths:
+              - path: /
+                pathType: Prefix
+                backend:
+                  service:
+                    name: baz-tls-com
+                    port:
+                      number: 8080
+    
+        - host: "*.wildcard.tls.com"
+          http:
+            paths:
+              - path: /
+                pathType: Prefix
+                backend:
+                  service:
+                    name: wildcard-tls-com
+                    port:
+                      number: 8080
+    
+        - host: unknown.tls.com
+          http:
+            paths:
+              - path: /
+                pathType: Prefix
+                backend:
+                  service:
+                    name: unknown-tls-com
+                    port:
+                      number: 8080
+    """
+    Then The Ingress status shows the IP address or FQDN where it is exposed
+
+  @conformance
+  Scenario Outline: An Ingress with multiple TLS entries should present the certificate matching the server name
+    (<description>)
+
+    When I send a "GET" request to "https://<host>"
+    Then the secure connection must verify the "<host>" hostname
+    And the certificate presented by the server must be the one stored in the "<secret>" secret
+    And the response status-code must be 200
+    And the response must be served by the "<service>" service
+
+    Examples:
+      | host               | secret       | service          | description                                                     |
+      | foo.tls.com        | foo-tls      | foo-tls-com      | secret with a single host                                       |
+      | bar.tls.com        | bar-tls      | bar-tls-com      | secret with a certificate valid for bar.tls.com and baz.tls.com |
+      | baz.tls.com        | baz-tls      | baz-tls-com      | host included in the certificates of two secrets                |
+      | a.wildcard.tls.com | wildcard-tls | wildcard-tls-com | wildcard certificate and wildcard host                          |
+
+  @informational
+  Scenario: An Ingress records the certificate presented for a server name not included in any TLS entry
+    When I send a "GET" request to "https://unknown.tls.com" that may fail
+    Then the certificate presented for the "unknown.tls.com" server name is recorded
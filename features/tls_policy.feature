@@ -0,0 +1,53 @@
+@sig-network @informational @release-1.19
+Feature: TLS policy
+  The TLS versions, cipher suites and ALPN protocols accepted by an ingress
+  controller depend on its configuration and are not defined by the Ingress
+  specification. This feature attempts TLS handshakes offering a single
+  version, cipher suite or ALPN protocol each time and records the result in
+  the report.
+  
+  The TLS versions that must be accepted or rejected can be configured using
+  the flags --accepted-tls-versions (for example TLS1.2,TLS1.3) and
+  --rejected-tls-versions (for example TLS1.0,TLS1.1).
+
+  Background:
+    Given a new random namespace
+    Given a self-signed TLS secret named "policy-tls" for the "tls.policy.com" hostname
+    Given an Ingress resource
+    """
+    apiVersion: networking.k8s.io/v1
+    kind: Ingress
+    metadata:
+      name: tls-policy
+    spec:
+      tls:
+        - hosts:
+            - tls.policy.com
+          secretName: policy-tls
+      rules:
+        - host: tls.policy.com
+          http:
+            paths:
+              - path: /
+                pathType: Prefix
+                backend:
+                  service:
+                    name: tls-policy-com
+                    port:
+                      number: 8080
+    """
+    Then The Ingress status shows the IP address or FQDN where it is exposed
+
+  Scenario: An Ingress records the TLS versions accepted by the ingress controller
+    When the TLS versions accepted for the "tls.policy.com" server name are probed
+    Then the results of the TLS probe are recorded
+    And the TLS versions configured as accepted must be accepted
+    And the TLS versions configured as rejected must be rejected
+
+  Scenario: An Ingress records the cipher suites accepted by the ingress controller
+    When the cipher suites accepted for the "tls.policy.com" server name are probed
+    Then the results of the TLS probe are recorded
+
+  Scenario: An Ingress records the ALPN protocols accepted by the ingress controller
+    When the ALPN protocols "h2,http/1.1" accepted for the "tls.policy.com" server name are probed
+    Then the results of the TLS probe are recorded
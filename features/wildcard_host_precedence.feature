@@ -0,0 +1,231 @@
+@sig-network @release-1.19
+Feature: Wildcard host precedence
+  A request host may match both the precise host and the wildcard host of
+  Ingress rules, like foo.bar.com and *.bar.com. The precise host takes
+  precedence over the wildcard host, whether the rules are defined in the
+  same Ingress or in different Ingresses. When the rules are defined in
+  different Ingresses, the status of every Ingress must show where it is
+  exposed before sending requests.
+  
+  Requests not matching any host, like bar.com or baz.foo.bar.com (the
+  wildcard only covers a single DNS label), are sent to the default backend.
+  
+  The Ingress specification does not define if a request matching the
+  precise host but none of its paths must be sent to a path of the wildcard
+  host. That scenario only records how the ingress controller handles the
+  request in the report.
+
+  Background:
+    Given a new random namespace
+
+  @conformance
+  Scenario Outline: An Ingress with precise and wildcard host rules should send traffic to the precise host
+    (request <host><path>)
+
+    Given an Ingress resource
+    """
+    apiVersion: networking.k8s.io/v1
+    kind: Ingress
+    metadata:
+      name: wildcard-precedence
+    spec:
+      defaultBackend:
+        service:
+          name: default-backend
+          port:
+            number: 8080
+      rules:
+        - host: foo.bar.com
+          http:
+            paths:
+              - path: /
+                pathType: Prefix
+                backend:
+                  service:
+                    name: foo-bar-com
+                    port:
+                      number: 8080
+
+        - host: "*.bar.com"
+          http:
+            paths:
+              - path: /
+                pathType: Prefix
+                backend:
+                  service:
+                    name: wildcard-bar-com
+                    port:
+                      number: 8080
+    """
+    Then The Ingress status shows the IP address or FQDN where it is exposed
+    When I send a "GET" request to "http://<host><path>"
+    Then the response status-code must be 200
+    And the response must be served by the "<service>" service
+    And the request host must be "<host>"
+
+    Examples:
+      | host            | path      | service          |
+      | foo.bar.com     | /         | foo-bar-com      |
+      | foo.bar.com     | /sub-path | foo-bar-com      |
+      | baz.bar.com     | /         | wildcard-bar-com |
+      | bar.com         | /         | default-backend  |
+      | baz.foo.bar.com | /         | default-backend  |
+      | foo.baz.com     | /         | default-backend  |
+
+  @conformance
+  Scenario Outline: Ingresses with precise and wildcard host rules should send traffic to the precise host
+    (request <host><path>, hosts defined in different Ingresses)
+
+    Given an Ingress resource
+    """
+    apiVersion: networking.k8s.io/v1
+    kind: Ingress
+    metadata:
+      name: exact-host
+    spec:
+      rules:
+        - host: foo.bar.com
+          http:
+            paths:
+              - path: /
+                pathType: Prefix
+                backend:
+                  service:
+                    name: foo-bar-com
+                    port:
+                      number: 8080
+    """
+    And an Ingress resource
+    """
+    apiVersion: networking.k8s.io/v1
+    kind: Ingress
+    metadata:
+      name: wildcard-host
+    spec:
+      defaultBackend:
+        service:
+          name: default-backend
+          port:
+            number: 8080
+      rules:
+        - host: "*.bar.com"
+          http:
+            paths:
+              - path: /
+                pathType: Prefix
+                backend:
+                  service:
+                    name: wildcard-bar-com
+                    port:
+                      number: 8080
+    """
+    Then The status of the Ingress "exact-host" shows the IP address or FQDN where it is exposed
+    And The Ingress status shows the IP address or FQDN where it is exposed
+    When I send a "GET" request to "http://<host><path>"
+    Then the response status-code must be 200
+    And the response must be served by the "<service>" service
+    And the request host must be "<host>"
+
+    Examples:
+      | host            | path      | service          |
+      | foo.bar.com     | /         | foo-bar-com      |
+      | foo.bar.com     | /sub-path | foo-bar-com      |
+      | baz.bar.com     | /         | wildcard-bar-com |
+      | bar.com         | /         | default-backend  |
+      | baz.foo.bar.com | /         | default-backend  |
+      | foo.baz.com     | /         | default-backend  |
+
+  @conformance
+  Scenario Outline: An Ingress with precise and wildcard host rules using different path types should send traffic to the precise host
+    (request <host><path>)
+
+    Given an Ingress resource
+    """
+    apiVersion: networking.k8s.io/v1
+    kind: Ingress
+    metadata:
+      name: wildcard-path-types
+    spec:
+      defaultBackend:
+        service:
+          name: default-backend
+          port:
+            number: 8080
+      rules:
+        - host: foo.bar.com
+          http:
+            paths:
+              - path: /app
+                pathType: Exact
+                backend:
+                  service:
+                    name: foo-bar-com
+                    port:
+                      number: 8080
+
+        - host: "*.bar.com"
+          http:
+            paths:
+              - path: /app
+                pathType: Prefix
+                backend:
+                  service:
+                    name: wildcard-bar-com
+                    port:
+                      number: 8080
+    """
+    Then The Ingress status shows the IP address or FQDN where it is exposed
+    When I send a "GET" request to "http://<host><path>"
+    Then the response status-code must be 200
+    And the response must be served by the "<service>" service
+    And the request host must be "<host>"
+
+    Examples:
+      | host        | path       | service          |
+      | foo.bar.com | /app       | foo-bar-com      |
+      | baz.bar.com | /app       | wildcard-bar-com |
+      | baz.bar.com | /app/sub   | wildcard-bar-com |
+      | bar.com     | /app       | default-backend  |
+
+  @informational
+  Scenario: An Ingress records the backend of a request matching the precise host but none of its paths
+    (request foo.bar.com/app/sub matches the wildcard host path /app)
+
+    Given an Ingress resource
+    """
+    apiVersion: networking.k8s.io/v1
+    kind: Ingress
+    metadata:
+      name: wildcard-path-types
+    spec:
+      defaultBackend:
+        service:
+          name: default-backend
+          port:
+            number: 8080
+      rules:
+        - host: foo.bar.com
+          http:
+            paths:
+              - path: /app
+                pathType: Exact
+                backend:
+                  service:
+                    name: foo-bar-com
+                    port:
+                      number: 8080
+
+        - host: "*.bar.com"
+          http:
+            paths:
+              - path: /app
+                pathType: Prefix
+                backend:
+                  service:
+                    name: wildcard-bar-com
+                    port:
+                      number: 8080
+    """
+    Then The Ingress status shows the IP address or FQDN where it is exposed
+    When I send a "GET" request to "http://foo.bar.com/app/sub" that may fail
+    Then the service serving the request is recorded
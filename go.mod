@@ -7,6 +7,7 @@ require (
 	github.com/cucumber/godog v0.11.0-rc1
 	github.com/cucumber/messages-go/v10 v10.0.3
 	github.com/iancoleman/orderedmap v0.1.0
+	golang.org/x/net v0.0.0-20200707034311-ab3426394381
 	golang.org/x/tools v0.0.0-20191227053925-7b8e75db28f4
 	k8s.io/api v0.19.2
 	k8s.io/apimachinery v0.19.2
@@ -17,24 +17,49 @@ limitations under the License.
 package main
 
 import (
+	"bufio"
+	gocontext "context"
+	"crypto/sha1"
+	"crypto/sha256"
 	"crypto/tls"
 	"crypto/x509"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
 	"encoding/json"
 	"encoding/pem"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
+	"net/url"
 	"os"
+	"os/signal"
+	"strconv"
 	"strings"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
 )
 
 // RequestAssertions contains information about the request and the Ingress
 type RequestAssertions struct {
-	Path    string              `json:"path"`
-	Host    string              `json:"host"`
-	Method  string              `json:"method"`
-	Proto   string              `json:"proto"`
-	Headers map[string][]string `json:"headers"`
+	Path       string              `json:"path"`
+	RawQuery   string              `json:"rawQuery"`
+	RequestURI string              `json:"requestURI"`
+	Host       string              `json:"host"`
+	Method     string              `json:"method"`
+	Proto      string              `json:"proto"`
+	Headers    map[string][]string `json:"headers"`
+	RemoteAddr string              `json:"remoteAddr"`
+
+	BodyLength int64  `json:"bodyLength"`
+	BodySHA256 string `json:"bodySHA256"`
+
+	InFlightRequests int64 `json:"inFlightRequests"`
 
 	Context `json:",inline"`
 
@@ -50,13 +75,46 @@ type TLSAssertions struct {
 	CipherSuite        string   `json:"cipherSuite"`
 }
 
-type preserveSlashes struct {
-	mux http.Handler
+// echoMux routes requests without cleaning the request path (http.ServeMux
+// redirects paths containing dot-segments or double slashes), so the echo
+// handler receives the request-target untouched.
+type echoMux struct{}
+
+func (m *echoMux) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case "/health":
+		healthHandler(w, r)
+	case "/live":
+		livenessHandler(w, r)
+	case "/admin/readiness":
+		readinessHandler(w, r)
+	case "/admin/stream":
+		trackInFlight(streamHandler)(w, r)
+	default:
+		if isWebSocketUpgrade(r) {
+			trackInFlight(websocketHandler)(w, r)
+			return
+		}
+
+		trackInFlight(echoHandler)(w, r)
+	}
 }
 
-func (s *preserveSlashes) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	r.URL.Path = strings.Replace(r.URL.Path, "//", "/", -1)
-	s.mux.ServeHTTP(w, r)
+var (
+	// ready is 1 when the server reports it is ready to handle requests
+	ready int32 = 1
+	// inFlightRequests number of echo and stream requests being handled
+	inFlightRequests int64
+)
+
+// trackInFlight counts the requests being handled by h
+func trackInFlight(h http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt64(&inFlightRequests, 1)
+		defer atomic.AddInt64(&inFlightRequests, -1)
+
+		h(w, r)
+	}
 }
 
 // Context contains information about the context where the echoserver is running
@@ -80,6 +138,15 @@ func main() {
 		httpsPort = "8443"
 	}
 
+	drainPeriod := defaultDrainPeriod
+	if value := os.Getenv("DRAIN_PERIOD"); value != "" {
+		var err error
+		drainPeriod, err = time.ParseDuration(value)
+		if err != nil {
+			panic(fmt.Sprintf("Invalid DRAIN_PERIOD value %s: %s\n", value, err.Error()))
+		}
+	}
+
 	context = Context{
 		Namespace: os.Getenv("NAMESPACE"),
 		Ingress:   os.Getenv("INGRESS_NAME"),
@@ -87,66 +154,462 @@ func main() {
 		Pod:       os.Getenv("POD_NAME"),
 	}
 
-	httpMux := http.NewServeMux()
-	httpMux.HandleFunc("/health", healthHandler)
-	httpMux.HandleFunc("/", echoHandler)
-	httpHandler := &preserveSlashes{httpMux}
+	httpHandler := &echoMux{}
 
 	errchan := make(chan error)
 
+	// the HTTP port also accepts HTTP/2 without TLS (h2c)
+	httpServer := &http.Server{
+		Addr:    fmt.Sprintf(":%s", httpPort),
+		Handler: h2c.NewHandler(httpHandler, &http2.Server{}),
+	}
+	servers := []*http.Server{httpServer}
+
 	go func() {
 		fmt.Printf("Starting server, listening on port %s (http)\n", httpPort)
-		err := http.ListenAndServe(fmt.Sprintf(":%s", httpPort), httpHandler)
-		if err != nil {
+		err := httpServer.ListenAndServe()
+		if err != nil && err != http.ErrServerClosed {
 			errchan <- err
 		}
 	}()
 
 	// Enable HTTPS if certificate and private key are given.
 	if os.Getenv("TLS_SERVER_CERT") != "" && os.Getenv("TLS_SERVER_PRIVKEY") != "" {
+		httpsServer, err := newTLSServer(fmt.Sprintf(":%s", httpsPort), os.Getenv("TLS_CLIENT_CACERTS"), httpHandler)
+		if err != nil {
+			panic(fmt.Sprintf("Failed to configure TLS: %s\n", err.Error()))
+		}
+		servers = append(servers, httpsServer)
+
 		go func() {
 			fmt.Printf("Starting server, listening on port %s (https)\n", httpsPort)
-			err := listenAndServeTLS(fmt.Sprintf(":%s", httpsPort), os.Getenv("TLS_SERVER_CERT"), os.Getenv("TLS_SERVER_PRIVKEY"), os.Getenv("TLS_CLIENT_CACERTS"), httpHandler)
-			if err != nil {
+			err := httpsServer.ListenAndServeTLS(os.Getenv("TLS_SERVER_CERT"), os.Getenv("TLS_SERVER_PRIVKEY"))
+			if err != nil && err != http.ErrServerClosed {
 				errchan <- err
 			}
 		}()
 	}
 
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGTERM, syscall.SIGINT)
+
 	select {
 	case err := <-errchan:
 		panic(fmt.Sprintf("Failed to start listening: %s\n", err.Error()))
+	case sig := <-signals:
+		shutdown(sig, servers, drainPeriod)
 	}
 }
 
+// defaultDrainPeriod time the servers keep accepting new requests after receiving a termination signal.
+// This gives time to the ingress controller to remove the pod from the endpoints of the service.
+const defaultDrainPeriod = 10 * time.Second
+
+// shutdownTimeout maximum time to wait for in-flight requests to finish after the drain period
+const shutdownTimeout = 10 * time.Second
+
+// shutdown reports the server is not ready and stops the servers after
+// the drain period, waiting for in-flight requests to finish
+func shutdown(sig os.Signal, servers []*http.Server, drainPeriod time.Duration) {
+	fmt.Printf("Received signal %v, draining requests for %v\n", sig, drainPeriod)
+	setReady(false)
+	time.Sleep(drainPeriod)
+
+	fmt.Printf("Waiting for %d in-flight requests\n", atomic.LoadInt64(&inFlightRequests))
+
+	ctx, cancel := gocontext.WithTimeout(gocontext.Background(), shutdownTimeout)
+	defer cancel()
+
+	for _, srv := range servers {
+		err := srv.Shutdown(ctx)
+		if err != nil {
+			fmt.Printf("Error shutting down server listening on %s: %s\n", srv.Addr, err.Error())
+		}
+	}
+
+	fmt.Println("Server stopped")
+}
+
+// healthHandler reports if the server is ready to handle requests
 func healthHandler(w http.ResponseWriter, r *http.Request) {
+	if atomic.LoadInt32(&ready) == 0 {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		w.Write([]byte(`NOT READY`))
+		return
+	}
+
 	w.WriteHeader(200)
 	w.Write([]byte(`OK`))
 }
 
+// livenessHandler reports the server is running, independently of its readiness
+func livenessHandler(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(200)
+	w.Write([]byte(`OK`))
+}
+
+// readinessHandler returns the readiness of the server and the number of in-flight requests.
+// POST and PUT requests change the readiness using the query parameter ready (true or false).
+func readinessHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodPost || r.Method == http.MethodPut {
+		value, err := strconv.ParseBool(r.URL.Query().Get("ready"))
+		if err != nil {
+			processError(w, fmt.Errorf("invalid ready value: %v", err), http.StatusBadRequest)
+			return
+		}
+
+		setReady(value)
+	}
+
+	js, err := json.MarshalIndent(struct {
+		Ready            bool   `json:"ready"`
+		InFlightRequests int64  `json:"inFlightRequests"`
+		Pod              string `json:"pod"`
+	}{
+		atomic.LoadInt32(&ready) == 1,
+		atomic.LoadInt64(&inFlightRequests),
+		context.Pod,
+	}, "", " ")
+	if err != nil {
+		processError(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Write(js)
+}
+
+func setReady(value bool) {
+	if value {
+		atomic.StoreInt32(&ready, 1)
+	} else {
+		atomic.StoreInt32(&ready, 0)
+	}
+
+	fmt.Printf("Readiness changed to %v\n", value)
+}
+
 func echoHandler(w http.ResponseWriter, r *http.Request) {
 	fmt.Printf("Echoing back request made to %s to client (%s)\n", r.RequestURI, r.RemoteAddr)
-	requestAssertions := RequestAssertions{
+
+	controls, err := responseControlsFromQuery(r.URL.Query())
+	if err != nil {
+		processError(w, err, http.StatusBadRequest)
+		return
+	}
+
+	hash := sha256.New()
+	bodyLength, err := io.Copy(hash, r.Body)
+	if err != nil {
+		processError(w, err, http.StatusBadRequest)
+		return
+	}
+
+	js, err := json.MarshalIndent(newRequestAssertions(r, bodyLength, hash.Sum(nil)), "", " ")
+	if err != nil {
+		processError(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	if controls.delay > 0 {
+		select {
+		case <-time.After(controls.delay):
+		case <-r.Context().Done():
+			return
+		}
+	}
+
+	if controls.abort {
+		// close the connection without any response, like a backend crashing while handling the request
+		panic(http.ErrAbortHandler)
+	}
+
+	for _, header := range controls.headers {
+		w.Header().Add(header[0], header[1])
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+
+	if controls.closeConnection {
+		// announce the complete body but only send half of it
+		w.Header().Set("Content-Length", strconv.Itoa(len(js)))
+		w.WriteHeader(controls.statusCode)
+		w.Write(js[:len(js)/2])
+		if flusher, ok := w.(http.Flusher); ok {
+			flusher.Flush()
+		}
+
+		// abort the response closing the connection (or resetting the HTTP/2 stream)
+		panic(http.ErrAbortHandler)
+	}
+
+	w.WriteHeader(controls.statusCode)
+	w.Write(js)
+}
+
+// newRequestAssertions returns the assertions about the request r, with a body of bodyLength bytes and SHA-256 hash bodySHA256
+func newRequestAssertions(r *http.Request, bodyLength int64, bodySHA256 []byte) RequestAssertions {
+	// the path is extracted from the request-target to avoid any decoding
+	path := strings.SplitN(r.RequestURI, "?", 2)[0]
+
+	return RequestAssertions{
+		path,
+		r.URL.RawQuery,
 		r.RequestURI,
 		r.Host,
 		r.Method,
 		r.Proto,
 		r.Header,
+		r.RemoteAddr,
+
+		bodyLength,
+		hex.EncodeToString(bodySHA256),
+
+		atomic.LoadInt64(&inFlightRequests),
 
 		context,
 
 		tlsStateToAssertions(r.TLS),
 	}
+}
+
+// Query parameters that control the response of the echo handler
+const (
+	// statusParam sets the status code of the response
+	statusParam = "echo-status"
+	// headerParam adds a header to the response, using the format name:value.
+	// The parameter can be repeated to add multiple headers.
+	headerParam = "echo-header"
+	// delayParam sets the duration to wait before sending the response
+	delayParam = "echo-delay"
+	// closeParam closes the connection after sending half of the response body
+	closeParam = "echo-close"
+	// abortParam closes the connection without sending a response
+	abortParam = "echo-abort"
+)
+
+// responseControls defines how the echo handler responds to a request
+type responseControls struct {
+	statusCode      int
+	headers         [][2]string
+	delay           time.Duration
+	closeConnection bool
+	abort           bool
+}
+
+func responseControlsFromQuery(query url.Values) (*responseControls, error) {
+	controls := &responseControls{
+		statusCode: http.StatusOK,
+	}
+
+	if value := query.Get(statusParam); value != "" {
+		statusCode, err := strconv.Atoi(value)
+		if err != nil || statusCode < 200 || statusCode > 599 {
+			return nil, fmt.Errorf("invalid %v value %v", statusParam, value)
+		}
+
+		controls.statusCode = statusCode
+	}
+
+	for _, value := range query[headerParam] {
+		header := strings.SplitN(value, ":", 2)
+		if len(header) != 2 || strings.TrimSpace(header[0]) == "" {
+			return nil, fmt.Errorf("invalid %v value %v", headerParam, value)
+		}
+
+		controls.headers = append(controls.headers, [2]string{strings.TrimSpace(header[0]), strings.TrimSpace(header[1])})
+	}
+
+	if value := query.Get(delayParam); value != "" {
+		delay, err := time.ParseDuration(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %v value %v: %v", delayParam, value, err)
+		}
+
+		controls.delay = delay
+	}
+
+	if value := query.Get(closeParam); value != "" {
+		closeConnection, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %v value %v: %v", closeParam, value, err)
+		}
+
+		controls.closeConnection = closeConnection
+	}
+
+	if value := query.Get(abortParam); value != "" {
+		abort, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %v value %v: %v", abortParam, value, err)
+		}
+
+		controls.abort = abort
+	}
+
+	return controls, nil
+}
+
+// streamHandler sends server-sent events at timed intervals. The number of events
+// and the interval between them are defined by the events and interval query parameters.
+func streamHandler(w http.ResponseWriter, r *http.Request) {
+	fmt.Printf("Streaming response to request made to %s to client (%s)\n", r.RequestURI, r.RemoteAddr)
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		processError(w, fmt.Errorf("streaming is not supported"), http.StatusInternalServerError)
+		return
+	}
+
+	events := 5
+	if value := r.URL.Query().Get("events"); value != "" {
+		var err error
+		events, err = strconv.Atoi(value)
+		if err != nil || events < 1 {
+			processError(w, fmt.Errorf("invalid events value %v", value), http.StatusBadRequest)
+			return
+		}
+	}
+
+	interval := 1 * time.Second
+	if value := r.URL.Query().Get("interval"); value != "" {
+		var err error
+		interval, err = time.ParseDuration(value)
+		if err != nil {
+			processError(w, fmt.Errorf("invalid interval value %v: %v", value, err), http.StatusBadRequest)
+			return
+		}
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(http.StatusOK)
+
+	for event := 1; event <= events; event++ {
+		if event > 1 {
+			select {
+			case <-time.After(interval):
+			case <-r.Context().Done():
+				return
+			}
+		}
+
+		data, err := json.Marshal(struct {
+			Event  int    `json:"event"`
+			Events int    `json:"events"`
+			Pod    string `json:"pod"`
+		}{
+			event,
+			events,
+			context.Pod,
+		})
+		if err != nil {
+			return
+		}
+
+		fmt.Fprintf(w, "id: %d\ndata: %s\n\n", event, data)
+		flusher.Flush()
+	}
+}
+
+// websocketGUID is concatenated to the Sec-WebSocket-Key header to compute the Sec-WebSocket-Accept header (RFC 6455)
+const websocketGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+// WebSocket frame opcodes used by the websocket handler
+const (
+	websocketTextFrame  = 0x1
+	websocketCloseFrame = 0x8
+)
+
+// isWebSocketUpgrade returns true if the request is a WebSocket opening handshake
+func isWebSocketUpgrade(r *http.Request) bool {
+	if !strings.EqualFold(r.Header.Get("Upgrade"), "websocket") {
+		return false
+	}
+
+	for _, value := range r.Header["Connection"] {
+		for _, token := range strings.Split(value, ",") {
+			if strings.EqualFold(strings.TrimSpace(token), "upgrade") {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// websocketHandler completes the WebSocket opening handshake, sends the request
+// assertions in a text message and closes the connection.
+func websocketHandler(w http.ResponseWriter, r *http.Request) {
+	fmt.Printf("Echoing back WebSocket request made to %s to client (%s)\n", r.RequestURI, r.RemoteAddr)
+
+	key := r.Header.Get("Sec-WebSocket-Key")
+	if key == "" || r.Header.Get("Sec-WebSocket-Version") != "13" {
+		processError(w, fmt.Errorf("invalid WebSocket handshake"), http.StatusBadRequest)
+		return
+	}
+
+	hash := sha256.New()
+	bodyLength, err := io.Copy(hash, r.Body)
+	if err != nil {
+		processError(w, err, http.StatusBadRequest)
+		return
+	}
 
-	js, err := json.MarshalIndent(requestAssertions, "", " ")
+	js, err := json.MarshalIndent(newRequestAssertions(r, bodyLength, hash.Sum(nil)), "", " ")
 	if err != nil {
 		processError(w, err, http.StatusInternalServerError)
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	w.Header().Set("X-Content-Type-Options", "nosniff")
-	w.Write(js)
+	hijacker, ok := w.(http.Hijacker)
+	if !ok {
+		processError(w, fmt.Errorf("WebSocket is not supported"), http.StatusInternalServerError)
+		return
+	}
+
+	conn, rw, err := hijacker.Hijack()
+	if err != nil {
+		processError(w, err, http.StatusInternalServerError)
+		return
+	}
+	defer conn.Close()
+
+	accept := sha1.Sum([]byte(key + websocketGUID))
+
+	fmt.Fprintf(rw, "HTTP/1.1 101 Switching Protocols\r\nUpgrade: websocket\r\nConnection: Upgrade\r\nSec-WebSocket-Accept: %s\r\n\r\n",
+		base64.StdEncoding.EncodeToString(accept[:]))
+
+	writeWebSocketFrame(rw.Writer, websocketTextFrame, js)
+	// status code 1000 (normal closure)
+	writeWebSocketFrame(rw.Writer, websocketCloseFrame, []byte{0x03, 0xe8})
+
+	err = rw.Flush()
+	if err != nil {
+		fmt.Printf("Error sending WebSocket response: %s\n", err.Error())
+	}
+}
+
+// writeWebSocketFrame writes an unmasked final frame (the server never masks frames)
+func writeWebSocketFrame(w *bufio.Writer, opcode byte, payload []byte) {
+	w.WriteByte(0x80 | opcode)
+
+	length := len(payload)
+	switch {
+	case length < 126:
+		w.WriteByte(byte(length))
+	case length <= 0xffff:
+		w.WriteByte(126)
+		binary.Write(w, binary.BigEndian, uint16(length))
+	default:
+		w.WriteByte(127)
+		binary.Write(w, binary.BigEndian, uint64(length))
+	}
+
+	w.Write(payload)
 }
 
 func processError(w http.ResponseWriter, err error, code int) {
@@ -167,19 +630,20 @@ func processError(w http.ResponseWriter, err error, code int) {
 	w.Write(body)
 }
 
-func listenAndServeTLS(addr string, serverCert string, serverPrivKey string, clientCA string, handler http.Handler) error {
+// newTLSServer returns a server configured to optionally verify client certificates
+func newTLSServer(addr string, clientCA string, handler http.Handler) (*http.Server, error) {
 	var config tls.Config
 
 	// Optionally enable client certificate validation when client CA certificates are given.
 	if clientCA != "" {
 		ca, err := ioutil.ReadFile(clientCA)
 		if err != nil {
-			return err
+			return nil, err
 		}
 
 		certPool := x509.NewCertPool()
 		if ok := certPool.AppendCertsFromPEM(ca); !ok {
-			return fmt.Errorf("unable to append certificate in %q to CA pool", clientCA)
+			return nil, fmt.Errorf("unable to append certificate in %q to CA pool", clientCA)
 		}
 
 		// Verify certificate against given CA but also allow unauthenticated connections.
@@ -193,7 +657,7 @@ func listenAndServeTLS(addr string, serverCert string, serverPrivKey string, cli
 		TLSConfig: &config,
 	}
 
-	return srv.ListenAndServeTLS(serverCert, serverPrivKey)
+	return srv, nil
 }
 
 func tlsStateToAssertions(connectionState *tls.ConnectionState) *TLSAssertions {
@@ -0,0 +1,266 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certs
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net"
+	"time"
+)
+
+// KeyType type of the private key of a certificate
+type KeyType string
+
+const (
+	// RSA 2048 bits RSA key
+	RSA KeyType = "RSA"
+	// ECDSA ECDSA key using the P-256 curve
+	ECDSA KeyType = "ECDSA"
+	// Ed25519 Ed25519 key
+	Ed25519 KeyType = "Ed25519"
+)
+
+const (
+	rsaBits = 2048
+	// DefaultValidity validity of certificates without explicit NotAfter
+	DefaultValidity = 365 * 24 * time.Hour
+	organization    = "Acme Co"
+)
+
+// Options customizes the generated certificates
+type Options struct {
+	// KeyType type of the private key (RSA if empty)
+	KeyType KeyType
+	// NotBefore start of the validity period (now if zero)
+	NotBefore time.Time
+	// NotAfter end of the validity period (NotBefore plus DefaultValidity if zero)
+	NotAfter time.Time
+}
+
+// Certificate contains a certificate, its private key and the certificate of the issuer
+type Certificate struct {
+	Certificate *x509.Certificate
+	PrivateKey  crypto.Signer
+	// Issuer certificate that signed this certificate (nil for self signed certificates)
+	Issuer *Certificate
+}
+
+// NewCA returns a new self signed certificate authority
+func NewCA(commonName string, options Options) (*Certificate, error) {
+	template := &x509.Certificate{
+		Subject: pkix.Name{
+			CommonName:   commonName,
+			Organization: []string{organization},
+		},
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+
+	return newCertificate(template, options, nil)
+}
+
+// NewSelfSigned returns a new self signed certificate valid for hosts
+func NewSelfSigned(hosts []string, options Options) (*Certificate, error) {
+	return newCertificate(leafTemplate(hosts), options, nil)
+}
+
+// NewIntermediate returns a new intermediate certificate authority signed by ca
+func (ca *Certificate) NewIntermediate(commonName string, options Options) (*Certificate, error) {
+	template := &x509.Certificate{
+		Subject: pkix.Name{
+			CommonName:   commonName,
+			Organization: []string{organization},
+		},
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		MaxPathLenZero:        true,
+	}
+
+	return newCertificate(template, options, ca)
+}
+
+// NewLeaf returns a new server certificate valid for hosts signed by ca
+func (ca *Certificate) NewLeaf(hosts []string, options Options) (*Certificate, error) {
+	return newCertificate(leafTemplate(hosts), options, ca)
+}
+
+// CertificatePEM returns the PEM encoded certificate
+func (c *Certificate) CertificatePEM() []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: c.Certificate.Raw})
+}
+
+// ChainPEM returns the PEM encoded certificate followed by the certificates
+// of the intermediate authorities. The root certificate is not included.
+func (c *Certificate) ChainPEM() []byte {
+	var chain bytes.Buffer
+	chain.Write(c.CertificatePEM())
+
+	for issuer := c.Issuer; issuer != nil && issuer.Issuer != nil; issuer = issuer.Issuer {
+		chain.Write(issuer.CertificatePEM())
+	}
+
+	return chain.Bytes()
+}
+
+// PrivateKeyPEM returns the PEM encoded private key
+func (c *Certificate) PrivateKeyPEM() ([]byte, error) {
+	var block *pem.Block
+
+	switch key := c.PrivateKey.(type) {
+	case *rsa.PrivateKey:
+		block = &pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}
+	case *ecdsa.PrivateKey:
+		der, err := x509.MarshalECPrivateKey(key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode key: %w", err)
+		}
+
+		block = &pem.Block{Type: "EC PRIVATE KEY", Bytes: der}
+	default:
+		der, err := x509.MarshalPKCS8PrivateKey(key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode key: %w", err)
+		}
+
+		block = &pem.Block{Type: "PRIVATE KEY", Bytes: der}
+	}
+
+	return pem.EncodeToMemory(block), nil
+}
+
+// CertPool returns a pool containing only this certificate, to be used as root
+func (c *Certificate) CertPool() *x509.CertPool {
+	pool := x509.NewCertPool()
+	pool.AddCert(c.Certificate)
+
+	return pool
+}
+
+func leafTemplate(hosts []string) *x509.Certificate {
+	template := &x509.Certificate{
+		Subject: pkix.Name{
+			CommonName:   "default",
+			Organization: []string{organization},
+		},
+		KeyUsage:              x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+	}
+
+	for _, h := range hosts {
+		if ip := net.ParseIP(h); ip != nil {
+			template.IPAddresses = append(template.IPAddresses, ip)
+		} else {
+			template.DNSNames = append(template.DNSNames, h)
+		}
+	}
+
+	return template
+}
+
+// newCertificate signs template with the key of issuer, or self signs it if issuer is nil
+func newCertificate(template *x509.Certificate, options Options, issuer *Certificate) (*Certificate, error) {
+	key, err := newPrivateKey(options.KeyType)
+	if err != nil {
+		return nil, err
+	}
+
+	serialNumberLimit := new(big.Int).Lsh(big.NewInt(1), 128)
+	serialNumber, err := rand.Int(rand.Reader, serialNumberLimit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate serial number: %w", err)
+	}
+
+	template.SerialNumber = serialNumber
+
+	template.NotBefore = options.NotBefore
+	if template.NotBefore.IsZero() {
+		template.NotBefore = time.Now()
+	}
+
+	template.NotAfter = options.NotAfter
+	if template.NotAfter.IsZero() {
+		template.NotAfter = template.NotBefore.Add(DefaultValidity)
+	}
+
+	// only RSA keys are used for key encipherment
+	if options.KeyType != "" && options.KeyType != RSA {
+		template.KeyUsage &^= x509.KeyUsageKeyEncipherment
+	}
+
+	parent, signer := template, key
+	if issuer != nil {
+		parent, signer = issuer.Certificate, issuer.PrivateKey
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, key.Public(), signer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create certificate: %w", err)
+	}
+
+	certificate, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse certificate: %w", err)
+	}
+
+	return &Certificate{
+		Certificate: certificate,
+		PrivateKey:  key,
+		Issuer:      issuer,
+	}, nil
+}
+
+func newPrivateKey(keyType KeyType) (crypto.Signer, error) {
+	switch keyType {
+	case "", RSA:
+		key, err := rsa.GenerateKey(rand.Reader, rsaBits)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate key: %w", err)
+		}
+
+		return key, nil
+	case ECDSA:
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate key: %w", err)
+		}
+
+		return key, nil
+	case Ed25519:
+		_, key, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate key: %w", err)
+		}
+
+		return key, nil
+	}
+
+	return nil, fmt.Errorf("unsupported key type %v", keyType)
+}
@@ -0,0 +1,161 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package appprotocol
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/cucumber/godog"
+	"github.com/cucumber/messages-go/v10"
+
+	"sigs.k8s.io/ingress-controller-conformance/test/kubernetes"
+	"sigs.k8s.io/ingress-controller-conformance/test/report"
+	tstate "sigs.k8s.io/ingress-controller-conformance/test/state"
+)
+
+var (
+	state *tstate.Scenario
+)
+
+// IMPORTANT: Steps definitions are generated and should not be modified
+// by hand but rather through make codegen. DO NOT EDIT.
+
+// InitializeScenario configures the Feature to test
+func InitializeScenario(ctx *godog.ScenarioContext) {
+	ctx.Step(`^an Ingress resource in a new random namespace with backend services using the "([^"]*)" application protocol$`, anIngressResourceInANewRandomNamespaceWithBackendServicesUsingTheApplicationProtocol)
+	ctx.Step(`^The Ingress status shows the IP address or FQDN where it is exposed$`, theIngressStatusShowsTheIPAddressOrFQDNWhereItIsExposed)
+	ctx.Step(`^I send a "([^"]*)" request to "([^"]*)" that may fail$`, iSendARequestToThatMayFail)
+	ctx.Step(`^the protocol used to connect to the backend service is recorded$`, theProtocolUsedToConnectToTheBackendServiceIsRecorded)
+	ctx.Step(`^the response status-code must be (\d+)$`, theResponseStatuscodeMustBe)
+	ctx.Step(`^the response must be served by the "([^"]*)" service$`, theResponseMustBeServedByTheService)
+	ctx.Step(`^the backend service must receive the request using the "([^"]*)" protocol$`, theBackendServiceMustReceiveTheRequestUsingTheProtocol)
+	ctx.Step(`^the backend service must receive the request "([^"]*)"$`, theBackendServiceMustReceiveTheRequest)
+	ctx.Step(`^I send a WebSocket request to "([^"]*)" that may fail$`, iSendAWebSocketRequestToThatMayFail)
+
+	ctx.BeforeScenario(func(*godog.Scenario) {
+		state = tstate.New()
+	})
+
+	ctx.AfterScenario(func(*messages.Pickle, error) {
+		// delete namespace an all the content
+		_ = kubernetes.DeleteNamespace(kubernetes.KubeClient, state.Namespace)
+	})
+}
+
+func anIngressResourceInANewRandomNamespaceWithBackendServicesUsingTheApplicationProtocol(appProtocol string, spec *messages.PickleStepArgument_PickleDocString) error {
+	ns, err := kubernetes.NewNamespace(kubernetes.KubeClient)
+	if err != nil {
+		return err
+	}
+
+	state.Namespace = ns
+
+	ingress, err := kubernetes.IngressFromManifest(state.Namespace, spec.GetContent())
+	if err != nil {
+		return err
+	}
+
+	options := kubernetes.BackendOptions{
+		AppProtocol: appProtocol,
+		// the echoserver only accepts TLS connections on the HTTPS port
+		TLS: appProtocol == "https",
+	}
+
+	err = kubernetes.DeploymentsFromIngressWithOptions(kubernetes.KubeClient, ingress, options)
+	if err != nil {
+		return err
+	}
+
+	err = kubernetes.NewIngress(kubernetes.KubeClient, state.Namespace, ingress)
+	if err != nil {
+		return err
+	}
+
+	state.IngressName = ingress.GetName()
+
+	return nil
+}
+
+func theIngressStatusShowsTheIPAddressOrFQDNWhereItIsExposed() error {
+	ingress, err := kubernetes.WaitForIngressAddress(kubernetes.KubeClient, state.Namespace, state.IngressName)
+	if err != nil {
+		return err
+	}
+
+	state.IPOrFQDN = ingress
+
+	return err
+}
+
+func iSendARequestToThatMayFail(method string, rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return err
+	}
+
+	state.CaptureRoundTripThatMayFail(method, u.Scheme, u.Host, u.Path, u.RawQuery)
+
+	return nil
+}
+
+func theProtocolUsedToConnectToTheBackendServiceIsRecorded() error {
+	if state.ObserveResponse() {
+		report.Observe("proto", state.CapturedRequest.Proto)
+		report.Observe("encrypted", state.CapturedRequest.TLS != nil)
+	}
+
+	return nil
+}
+
+func theResponseStatuscodeMustBe(statusCode int) error {
+	if state.RequestError != nil {
+		return state.RequestError
+	}
+
+	return state.AssertStatusCode(statusCode)
+}
+
+func theResponseMustBeServedByTheService(service string) error {
+	return state.AssertServedBy(service)
+}
+
+func theBackendServiceMustReceiveTheRequestUsingTheProtocol(proto string) error {
+	return state.AssertRequestProto(proto)
+}
+
+func theBackendServiceMustReceiveTheRequest(connection string) error {
+	switch connection {
+	case "encrypted":
+		return state.AssertRequestTLS(true)
+	case "unencrypted":
+		return state.AssertRequestTLS(false)
+	default:
+		return fmt.Errorf("unexpected connection %v (encrypted or unencrypted)", connection)
+	}
+}
+
+func iSendAWebSocketRequestToThatMayFail(rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return err
+	}
+
+	state.RequestError = state.CaptureWebSocket(u.Scheme, u.Host, u.Path)
+
+	return nil
+}
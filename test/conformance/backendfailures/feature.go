@@ -0,0 +1,179 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backendfailures
+
+import (
+	"net/url"
+	"time"
+
+	"github.com/cucumber/godog"
+	"github.com/cucumber/messages-go/v10"
+
+	"sigs.k8s.io/ingress-controller-conformance/test/http"
+	"sigs.k8s.io/ingress-controller-conformance/test/kubernetes"
+	"sigs.k8s.io/ingress-controller-conformance/test/report"
+	tstate "sigs.k8s.io/ingress-controller-conformance/test/state"
+)
+
+var (
+	state *tstate.Scenario
+
+	// requestDuration time elapsed sending the last request that may fail
+	requestDuration time.Duration
+)
+
+// IMPORTANT: Steps definitions are generated and should not be modified
+// by hand but rather through make codegen. DO NOT EDIT.
+
+// InitializeScenario configures the Feature to test
+func InitializeScenario(ctx *godog.ScenarioContext) {
+	ctx.Step(`^an Ingress resource in a new random namespace$`, anIngressResourceInANewRandomNamespace)
+	ctx.Step(`^The Ingress status shows the IP address or FQDN where it is exposed$`, theIngressStatusShowsTheIPAddressOrFQDNWhereItIsExposed)
+	ctx.Step(`^the "([^"]*)" service has no ready endpoints$`, theServiceHasNoReadyEndpoints)
+	ctx.Step(`^I send a "([^"]*)" request to "([^"]*)" that may fail$`, iSendARequestToThatMayFail)
+	ctx.Step(`^the response to the "([^"]*)" failure is recorded$`, theResponseToTheFailureIsRecorded)
+	ctx.Step(`^the "([^"]*)" service recovers with (\d+) ready endpoints$`, theServiceRecoversWithReadyEndpoints)
+	ctx.Step(`^I send a "([^"]*)" request to "([^"]*)"$`, iSendARequestTo)
+	ctx.Step(`^the response status-code must be (\d+)$`, theResponseStatuscodeMustBe)
+	ctx.Step(`^the response must be served by the "([^"]*)" service$`, theResponseMustBeServedByTheService)
+	ctx.Step(`^the backend service closes the connection without sending a response$`, theBackendServiceClosesTheConnectionWithoutSendingAResponse)
+	ctx.Step(`^the backend service recovers$`, theBackendServiceRecovers)
+	ctx.Step(`^the backend service closes the connection in the middle of the response$`, theBackendServiceClosesTheConnectionInTheMiddleOfTheResponse)
+	ctx.Step(`^the backend service delays the response (\d+) seconds$`, theBackendServiceDelaysTheResponseSeconds)
+	ctx.Step(`^the backend service delays the response longer than the timeout of the ingress controller$`, theBackendServiceDelaysTheResponseLongerThanTheTimeoutOfTheIngressController)
+
+	ctx.BeforeScenario(func(*godog.Scenario) {
+		state = tstate.New()
+	})
+
+	ctx.AfterScenario(func(*messages.Pickle, error) {
+		// delete namespace an all the content
+		_ = kubernetes.DeleteNamespace(kubernetes.KubeClient, state.Namespace)
+	})
+}
+
+func anIngressResourceInANewRandomNamespace(spec *messages.PickleStepArgument_PickleDocString) error {
+	ns, err := kubernetes.NewNamespace(kubernetes.KubeClient)
+	if err != nil {
+		return err
+	}
+
+	state.Namespace = ns
+
+	ingress, err := kubernetes.IngressFromManifest(state.Namespace, spec.GetContent())
+	if err != nil {
+		return err
+	}
+
+	err = kubernetes.DeploymentsFromIngress(kubernetes.KubeClient, ingress)
+	if err != nil {
+		return err
+	}
+
+	err = kubernetes.NewIngress(kubernetes.KubeClient, state.Namespace, ingress)
+	if err != nil {
+		return err
+	}
+
+	state.IngressName = ingress.GetName()
+
+	return nil
+}
+
+func theIngressStatusShowsTheIPAddressOrFQDNWhereItIsExposed() error {
+	ingress, err := kubernetes.WaitForIngressAddress(kubernetes.KubeClient, state.Namespace, state.IngressName)
+	if err != nil {
+		return err
+	}
+
+	state.IPOrFQDN = ingress
+
+	return err
+}
+
+func theServiceHasNoReadyEndpoints(service string) error {
+	return kubernetes.ScaleIngressBackendDeployment(kubernetes.KubeClient, state.Namespace, state.IngressName, service, 0)
+}
+
+func iSendARequestToThatMayFail(method string, rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return err
+	}
+
+	start := time.Now()
+	state.CaptureRoundTripThatMayFail(method, u.Scheme, u.Host, u.Path, u.RawQuery)
+	requestDuration = time.Since(start)
+
+	return nil
+}
+
+func theResponseToTheFailureIsRecorded(failure string) error {
+	report.Observe("failure", failure)
+	report.Observe("latency", requestDuration.Round(time.Millisecond).String())
+
+	if state.ObserveResponse() {
+		report.Observe("contentLength", state.CapturedResponse.ContentLength)
+	}
+
+	return nil
+}
+
+func theServiceRecoversWithReadyEndpoints(service string, replicas int) error {
+	return kubernetes.ScaleIngressBackendDeployment(kubernetes.KubeClient, state.Namespace, state.IngressName, service, replicas)
+}
+
+func iSendARequestTo(method string, rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return err
+	}
+	return state.CaptureRoundTrip(method, u.Scheme, u.Host, u.Path, u.RawQuery)
+}
+
+func theResponseStatuscodeMustBe(statusCode int) error {
+	return state.AssertStatusCode(statusCode)
+}
+
+func theResponseMustBeServedByTheService(service string) error {
+	return state.AssertServedBy(service)
+}
+
+func theBackendServiceClosesTheConnectionWithoutSendingAResponse() error {
+	state.SetBackendAbort()
+	return nil
+}
+
+func theBackendServiceRecovers() error {
+	state.ResetBackendResponse()
+	return nil
+}
+
+func theBackendServiceClosesTheConnectionInTheMiddleOfTheResponse() error {
+	state.SetBackendCloseConnection()
+	return nil
+}
+
+func theBackendServiceDelaysTheResponseSeconds(seconds int) error {
+	state.SetBackendDelay(time.Duration(seconds) * time.Second)
+	return nil
+}
+
+func theBackendServiceDelaysTheResponseLongerThanTheTimeoutOfTheIngressController() error {
+	state.SetBackendDelay(http.SlowBackendDelay)
+	return nil
+}
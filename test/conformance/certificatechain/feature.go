@@ -0,0 +1,177 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificatechain
+
+import (
+	"net/url"
+
+	"github.com/cucumber/godog"
+	"github.com/cucumber/messages-go/v10"
+
+	"sigs.k8s.io/ingress-controller-conformance/test/certs"
+	"sigs.k8s.io/ingress-controller-conformance/test/kubernetes"
+	"sigs.k8s.io/ingress-controller-conformance/test/report"
+	tstate "sigs.k8s.io/ingress-controller-conformance/test/state"
+)
+
+var (
+	state *tstate.Scenario
+
+	rootCA         *certs.Certificate
+	intermediateCA *certs.Certificate
+)
+
+// IMPORTANT: Steps definitions are generated and should not be modified
+// by hand but rather through make codegen. DO NOT EDIT.
+
+// InitializeScenario configures the Feature to test
+func InitializeScenario(ctx *godog.ScenarioContext) {
+	ctx.Step(`^a new random namespace$`, aNewRandomNamespace)
+	ctx.Step(`^a certificate authority with an intermediate certificate authority$`, aCertificateAuthorityWithAnIntermediateCertificateAuthority)
+	ctx.Step(`^a TLS secret named "([^"]*)" with a "([^"]*)" key for the "([^"]*)" hostname issued by the intermediate certificate authority$`, aTLSSecretNamedWithAKeyForTheHostnameIssuedByTheIntermediateCertificateAuthority)
+	ctx.Step(`^an Ingress resource$`, anIngressResource)
+	ctx.Step(`^The Ingress status shows the IP address or FQDN where it is exposed$`, theIngressStatusShowsTheIPAddressOrFQDNWhereItIsExposed)
+	ctx.Step(`^I send a "([^"]*)" request to "([^"]*)"$`, iSendARequestTo)
+	ctx.Step(`^the certificate chain presented by the server must be verified by the certificate authority for the "([^"]*)" hostname$`, theCertificateChainPresentedByTheServerMustBeVerifiedByTheCertificateAuthorityForTheHostname)
+	ctx.Step(`^the response status-code must be (\d+)$`, theResponseStatuscodeMustBe)
+	ctx.Step(`^the response must be served by the "([^"]*)" service$`, theResponseMustBeServedByTheService)
+	ctx.Step(`^I send a "([^"]*)" request to "([^"]*)" that may fail$`, iSendARequestToThatMayFail)
+	ctx.Step(`^the verification of the certificate chain presented for the "([^"]*)" hostname is recorded$`, theVerificationOfTheCertificateChainPresentedForTheHostnameIsRecorded)
+
+	ctx.BeforeScenario(func(*godog.Scenario) {
+		state = tstate.New()
+	})
+
+	ctx.AfterScenario(func(*messages.Pickle, error) {
+		// delete namespace an all the content
+		_ = kubernetes.DeleteNamespace(kubernetes.KubeClient, state.Namespace)
+	})
+}
+
+func aNewRandomNamespace() error {
+	ns, err := kubernetes.NewNamespace(kubernetes.KubeClient)
+	if err != nil {
+		return err
+	}
+
+	state.Namespace = ns
+	return nil
+}
+
+func aCertificateAuthorityWithAnIntermediateCertificateAuthority() error {
+	var err error
+	rootCA, err = certs.NewCA("conformance-root-ca", certs.Options{})
+	if err != nil {
+		return err
+	}
+
+	intermediateCA, err = rootCA.NewIntermediate("conformance-intermediate-ca", certs.Options{})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func aTLSSecretNamedWithAKeyForTheHostnameIssuedByTheIntermediateCertificateAuthority(secretName string, keyType string, host string) error {
+	certificate, err := intermediateCA.NewLeaf([]string{host}, certs.Options{KeyType: certs.KeyType(keyType)})
+	if err != nil {
+		return err
+	}
+
+	return kubernetes.NewTLSSecretFromCertificate(kubernetes.KubeClient, state.Namespace, secretName, certificate)
+}
+
+func anIngressResource(spec *messages.PickleStepArgument_PickleDocString) error {
+	ingress, err := kubernetes.IngressFromManifest(state.Namespace, spec.GetContent())
+	if err != nil {
+		return err
+	}
+
+	err = kubernetes.DeploymentsFromIngress(kubernetes.KubeClient, ingress)
+	if err != nil {
+		return err
+	}
+
+	err = kubernetes.NewIngress(kubernetes.KubeClient, state.Namespace, ingress)
+	if err != nil {
+		return err
+	}
+
+	state.IngressName = ingress.GetName()
+
+	return nil
+}
+
+func theIngressStatusShowsTheIPAddressOrFQDNWhereItIsExposed() error {
+	ingress, err := kubernetes.WaitForIngressAddress(kubernetes.KubeClient, state.Namespace, state.IngressName)
+	if err != nil {
+		return err
+	}
+
+	state.IPOrFQDN = ingress
+
+	return err
+}
+
+func iSendARequestTo(method string, rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return err
+	}
+	return state.CaptureRoundTrip(method, u.Scheme, u.Host, u.Path, u.RawQuery)
+}
+
+func theCertificateChainPresentedByTheServerMustBeVerifiedByTheCertificateAuthorityForTheHostname(hostname string) error {
+	return state.AssertCertificateChain(rootCA.CertPool(), hostname)
+}
+
+func theResponseStatuscodeMustBe(statusCode int) error {
+	return state.AssertStatusCode(statusCode)
+}
+
+func theResponseMustBeServedByTheService(service string) error {
+	return state.AssertServedBy(service)
+}
+
+func iSendARequestToThatMayFail(method string, rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return err
+	}
+
+	state.CaptureRoundTripThatMayFail(method, u.Scheme, u.Host, u.Path, u.RawQuery)
+	return nil
+}
+
+func theVerificationOfTheCertificateChainPresentedForTheHostnameIsRecorded(hostname string) error {
+	report.Observe("hostname", hostname)
+
+	if !state.ObserveResponse() {
+		return nil
+	}
+	report.Observe("publicKeyAlgorithm", state.CapturedResponse.Certificate.PublicKeyAlgorithm.String())
+	report.Observe("chainLength", len(state.CapturedResponse.Certificates))
+
+	err := state.AssertCertificateChain(rootCA.CertPool(), hostname)
+	report.Observe("verified", err == nil)
+	if err != nil {
+		report.Observe("verificationError", err.Error())
+	}
+
+	return nil
+}
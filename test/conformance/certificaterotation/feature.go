@@ -0,0 +1,207 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificaterotation
+
+import (
+	"crypto/x509"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/cucumber/godog"
+	"github.com/cucumber/messages-go/v10"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	"sigs.k8s.io/ingress-controller-conformance/test/kubernetes"
+	"sigs.k8s.io/ingress-controller-conformance/test/report"
+	tstate "sigs.k8s.io/ingress-controller-conformance/test/state"
+)
+
+var (
+	state *tstate.Scenario
+
+	// previousCertificate certificate presented by the server before the renewal
+	previousCertificate *x509.Certificate
+	// renewedAt time when the secret was updated
+	renewedAt time.Time
+	// rotationTime time elapsed until the server presented the renewed certificate
+	rotationTime time.Duration
+)
+
+// IMPORTANT: Steps definitions are generated and should not be modified
+// by hand but rather through make codegen. DO NOT EDIT.
+
+// InitializeScenario configures the Feature to test
+func InitializeScenario(ctx *godog.ScenarioContext) {
+	ctx.Step(`^a new random namespace$`, aNewRandomNamespace)
+	ctx.Step(`^a self-signed TLS secret named "([^"]*)" for the "([^"]*)" hostname$`, aSelfsignedTLSSecretNamedForTheHostname)
+	ctx.Step(`^an Ingress resource$`, anIngressResource)
+	ctx.Step(`^The Ingress status shows the IP address or FQDN where it is exposed$`, theIngressStatusShowsTheIPAddressOrFQDNWhereItIsExposed)
+	ctx.Step(`^I send a "([^"]*)" request to "([^"]*)"$`, iSendARequestTo)
+	ctx.Step(`^the certificate presented by the server must be the one stored in the "([^"]*)" secret$`, theCertificatePresentedByTheServerMustBeTheOneStoredInTheSecret)
+	ctx.Step(`^the certificate stored in the "([^"]*)" secret is renewed$`, theCertificateStoredInTheSecretIsRenewed)
+	ctx.Step(`^the certificate presented for "([^"]*)" must be the one stored in the "([^"]*)" secret within the configured time$`, theCertificatePresentedForMustBeTheOneStoredInTheSecretWithinTheConfiguredTime)
+	ctx.Step(`^the serial number of the certificate presented by the server must be different from the previous one$`, theSerialNumberOfTheCertificatePresentedByTheServerMustBeDifferentFromThePreviousOne)
+	ctx.Step(`^the response status-code must be (\d+)$`, theResponseStatuscodeMustBe)
+	ctx.Step(`^the response must be served by the "([^"]*)" service$`, theResponseMustBeServedByTheService)
+	ctx.Step(`^the time elapsed until the renewed certificate was presented is recorded$`, theTimeElapsedUntilTheRenewedCertificateWasPresentedIsRecorded)
+
+	ctx.BeforeScenario(func(*godog.Scenario) {
+		state = tstate.New()
+	})
+
+	ctx.AfterScenario(func(*messages.Pickle, error) {
+		// delete namespace an all the content
+		_ = kubernetes.DeleteNamespace(kubernetes.KubeClient, state.Namespace)
+	})
+}
+
+func aNewRandomNamespace() error {
+	ns, err := kubernetes.NewNamespace(kubernetes.KubeClient)
+	if err != nil {
+		return err
+	}
+
+	state.Namespace = ns
+	return nil
+}
+
+func aSelfsignedTLSSecretNamedForTheHostname(secretName string, host string) error {
+	err := kubernetes.NewSelfSignedSecret(kubernetes.KubeClient, state.Namespace, secretName, []string{host})
+	if err != nil {
+		return err
+	}
+
+	state.SecretName = secretName
+
+	return nil
+}
+
+func anIngressResource(spec *messages.PickleStepArgument_PickleDocString) error {
+	ingress, err := kubernetes.IngressFromManifest(state.Namespace, spec.GetContent())
+	if err != nil {
+		return err
+	}
+
+	err = kubernetes.DeploymentsFromIngress(kubernetes.KubeClient, ingress)
+	if err != nil {
+		return err
+	}
+
+	err = kubernetes.NewIngress(kubernetes.KubeClient, state.Namespace, ingress)
+	if err != nil {
+		return err
+	}
+
+	state.IngressName = ingress.GetName()
+
+	return nil
+}
+
+func theIngressStatusShowsTheIPAddressOrFQDNWhereItIsExposed() error {
+	ingress, err := kubernetes.WaitForIngressAddress(kubernetes.KubeClient, state.Namespace, state.IngressName)
+	if err != nil {
+		return err
+	}
+
+	state.IPOrFQDN = ingress
+
+	return err
+}
+
+func iSendARequestTo(method string, rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return err
+	}
+	return state.CaptureRoundTrip(method, u.Scheme, u.Host, u.Path, u.RawQuery)
+}
+
+func theCertificatePresentedByTheServerMustBeTheOneStoredInTheSecret(secretName string) error {
+	certificate, err := kubernetes.SecretCertificate(kubernetes.KubeClient, state.Namespace, secretName)
+	if err != nil {
+		return err
+	}
+
+	return state.AssertServedCertificate(certificate)
+}
+
+func theCertificateStoredInTheSecretIsRenewed(secretName string) error {
+	previousCertificate = state.CapturedResponse.Certificate
+
+	err := kubernetes.RenewSelfSignedSecret(kubernetes.KubeClient, state.Namespace, secretName)
+	if err != nil {
+		return err
+	}
+
+	renewedAt = time.Now()
+	return nil
+}
+
+func theCertificatePresentedForMustBeTheOneStoredInTheSecretWithinTheConfiguredTime(rawURL string, secretName string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return err
+	}
+
+	certificate, err := kubernetes.SecretCertificate(kubernetes.KubeClient, state.Namespace, secretName)
+	if err != nil {
+		return err
+	}
+
+	var lastErr error
+	err = wait.PollImmediate(2*time.Second, kubernetes.WaitForCertificateRotationTimeout, func() (bool, error) {
+		lastErr = state.CaptureRoundTrip("GET", u.Scheme, u.Host, u.Path, u.RawQuery)
+		if lastErr != nil {
+			return false, nil
+		}
+
+		lastErr = state.AssertServedCertificate(certificate)
+		return lastErr == nil, nil
+	})
+	if err != nil {
+		return fmt.Errorf("waiting for the renewed certificate: %v", lastErr)
+	}
+
+	rotationTime = time.Since(renewedAt)
+	return nil
+}
+
+func theSerialNumberOfTheCertificatePresentedByTheServerMustBeDifferentFromThePreviousOne() error {
+	served := state.CapturedResponse.Certificate
+	if served.SerialNumber.Cmp(previousCertificate.SerialNumber) == 0 {
+		return fmt.Errorf("expected a certificate with a serial number different from %v", previousCertificate.SerialNumber)
+	}
+
+	return nil
+}
+
+func theResponseStatuscodeMustBe(statusCode int) error {
+	return state.AssertStatusCode(statusCode)
+}
+
+func theResponseMustBeServedByTheService(service string) error {
+	return state.AssertServedBy(service)
+}
+
+func theTimeElapsedUntilTheRenewedCertificateWasPresentedIsRecorded() error {
+	report.Observe("previousSerialNumber", previousCertificate.SerialNumber.String())
+	report.Observe("serialNumber", state.CapturedResponse.Certificate.SerialNumber.String())
+	report.Observe("rotationTime", rotationTime.Round(time.Second).String())
+
+	return nil
+}
@@ -100,7 +100,7 @@ func theIngressStatusShowsTheIPAddressOrFQDNWhereItIsExposed() error {
 }
 
 func iSendARequestToHttp(method string, hostname string, path string) error {
-	return state.CaptureRoundTrip(method, "http", hostname, path)
+	return state.CaptureRoundTrip(method, "http", hostname, path, "")
 }
 
 func theResponseStatuscodeMustBe(statusCode int) error {
@@ -0,0 +1,189 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dualstack
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/cucumber/godog"
+	"github.com/cucumber/messages-go/v10"
+
+	"sigs.k8s.io/ingress-controller-conformance/test/http"
+	"sigs.k8s.io/ingress-controller-conformance/test/kubernetes"
+	"sigs.k8s.io/ingress-controller-conformance/test/report"
+	tstate "sigs.k8s.io/ingress-controller-conformance/test/state"
+)
+
+var (
+	state *tstate.Scenario
+
+	// ipFamilies IP families used to send each request
+	ipFamilies = []string{kubernetes.IPv4AddressFamily, kubernetes.IPv6AddressFamily}
+
+	// familyAddresses address of the Ingress status by IP family
+	familyAddresses map[string]string
+
+	// capturedRequests and capturedResponses by IP family
+	capturedRequests  map[string]*http.CapturedRequest
+	capturedResponses map[string]*http.CapturedResponse
+)
+
+// IMPORTANT: Steps definitions are generated and should not be modified
+// by hand but rather through make codegen. DO NOT EDIT.
+
+// InitializeScenario configures the Feature to test
+func InitializeScenario(ctx *godog.ScenarioContext) {
+	ctx.Step(`^an Ingress resource in a new random namespace$`, anIngressResourceInANewRandomNamespace)
+	ctx.Step(`^The Ingress status shows the IP address or FQDN where it is exposed$`, theIngressStatusShowsTheIPAddressOrFQDNWhereItIsExposed)
+	ctx.Step(`^the IP families in the Ingress status are recorded$`, theIPFamiliesInTheIngressStatusAreRecorded)
+	ctx.Step(`^I send a "([^"]*)" request to "([^"]*)" using each IP family in the Ingress status$`, iSendARequestToUsingEachIPFamilyInTheIngressStatus)
+	ctx.Step(`^the response status-code must be (\d+) for each IP family$`, theResponseStatuscodeMustBeForEachIPFamily)
+	ctx.Step(`^the response must be served by the "([^"]*)" service for each IP family$`, theResponseMustBeServedByTheServiceForEachIPFamily)
+	ctx.Step(`^the request received by the backend service must be the same for each IP family$`, theRequestReceivedByTheBackendServiceMustBeTheSameForEachIPFamily)
+
+	ctx.BeforeScenario(func(*godog.Scenario) {
+		state = tstate.New()
+		familyAddresses = make(map[string]string)
+		capturedRequests = make(map[string]*http.CapturedRequest)
+		capturedResponses = make(map[string]*http.CapturedResponse)
+	})
+
+	ctx.AfterScenario(func(*messages.Pickle, error) {
+		// delete namespace an all the content
+		_ = kubernetes.DeleteNamespace(kubernetes.KubeClient, state.Namespace)
+	})
+}
+
+func anIngressResourceInANewRandomNamespace(spec *messages.PickleStepArgument_PickleDocString) error {
+	ns, err := kubernetes.NewNamespace(kubernetes.KubeClient)
+	if err != nil {
+		return err
+	}
+
+	state.Namespace = ns
+
+	ingress, err := kubernetes.IngressFromManifest(state.Namespace, spec.GetContent())
+	if err != nil {
+		return err
+	}
+
+	err = kubernetes.DeploymentsFromIngress(kubernetes.KubeClient, ingress)
+	if err != nil {
+		return err
+	}
+
+	err = kubernetes.NewIngress(kubernetes.KubeClient, state.Namespace, ingress)
+	if err != nil {
+		return err
+	}
+
+	state.IngressName = ingress.GetName()
+
+	return nil
+}
+
+func theIngressStatusShowsTheIPAddressOrFQDNWhereItIsExposed() error {
+	statusAddresses, err := kubernetes.WaitForIngressStatusAddresses(kubernetes.KubeClient, state.Namespace, state.IngressName)
+	if err != nil {
+		return err
+	}
+
+	var addresses []string
+	for _, address := range statusAddresses {
+		addresses = append(addresses, address.Address())
+	}
+
+	state.IPOrFQDN = kubernetes.SelectIngressAddress(addresses, kubernetes.IngressAddressFamily)
+	if state.IPOrFQDN == "" {
+		return fmt.Errorf("the Ingress status does not contain an address of the %v family", kubernetes.IngressAddressFamily)
+	}
+
+	for _, family := range ipFamilies {
+		if address := kubernetes.SelectIngressAddress(addresses, family); address != "" {
+			familyAddresses[family] = address
+		}
+	}
+
+	return nil
+}
+
+func theIPFamiliesInTheIngressStatusAreRecorded() error {
+	report.Observe("addresses", familyAddresses)
+	report.Observe("dualStack", isDualStack())
+
+	return nil
+}
+
+func iSendARequestToUsingEachIPFamilyInTheIngressStatus(method string, rawURL string) error {
+	if !isDualStack() {
+		return fmt.Errorf("the Ingress status does not contain both IPv4 and IPv6 addresses: %v", familyAddresses)
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return err
+	}
+
+	for _, family := range ipFamilies {
+		capturedRequest, capturedResponse, err := http.CaptureRoundTrip(method, u.Scheme, u.Host, u.Path, u.RawQuery, familyAddresses[family])
+		if err != nil {
+			return fmt.Errorf("sending request using %v address %v: %w", family, familyAddresses[family], err)
+		}
+
+		capturedRequests[family] = capturedRequest
+		capturedResponses[family] = capturedResponse
+	}
+
+	return nil
+}
+
+func theResponseStatuscodeMustBeForEachIPFamily(statusCode int) error {
+	for family, capturedResponse := range capturedResponses {
+		if capturedResponse.StatusCode != statusCode {
+			return fmt.Errorf("expected status code %v using %v but %v was returned", statusCode, family, capturedResponse.StatusCode)
+		}
+	}
+
+	return nil
+}
+
+func theResponseMustBeServedByTheServiceForEachIPFamily(service string) error {
+	for family, capturedRequest := range capturedRequests {
+		if capturedRequest.Service != service {
+			return fmt.Errorf("expected the request using %v to be served by %v but it was served by %v", family, service, capturedRequest.Service)
+		}
+	}
+
+	return nil
+}
+
+func theRequestReceivedByTheBackendServiceMustBeTheSameForEachIPFamily() error {
+	ipv4, ipv6 := capturedRequests[kubernetes.IPv4AddressFamily], capturedRequests[kubernetes.IPv6AddressFamily]
+
+	if ipv4.Method != ipv6.Method || ipv4.Host != ipv6.Host || ipv4.RequestURI != ipv6.RequestURI {
+		return fmt.Errorf("expected the same request using both families but %v %v%v (ipv4) and %v %v%v (ipv6) were received",
+			ipv4.Method, ipv4.Host, ipv4.RequestURI, ipv6.Method, ipv6.Host, ipv6.RequestURI)
+	}
+
+	return nil
+}
+
+// isDualStack returns true if the Ingress status contains IPv4 and IPv6 addresses
+func isDualStack() bool {
+	return len(familyAddresses) == len(ipFamilies)
+}
@@ -0,0 +1,177 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package endpointreadiness
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/cucumber/godog"
+	"github.com/cucumber/messages-go/v10"
+
+	"sigs.k8s.io/ingress-controller-conformance/test/kubernetes"
+	tstate "sigs.k8s.io/ingress-controller-conformance/test/state"
+)
+
+var (
+	state *tstate.Scenario
+
+	// notReadyPod name of the pod that reports it is not ready
+	notReadyPod string
+
+	statusCodes map[int]int
+	pods        map[string]int
+)
+
+// IMPORTANT: Steps definitions are generated and should not be modified
+// by hand but rather through make codegen. DO NOT EDIT.
+
+// InitializeScenario configures the Feature to test
+func InitializeScenario(ctx *godog.ScenarioContext) {
+	ctx.Step(`^an Ingress resource in a new random namespace$`, anIngressResourceInANewRandomNamespace)
+	ctx.Step(`^The Ingress status shows the IP address or FQDN where it is exposed$`, theIngressStatusShowsTheIPAddressOrFQDNWhereItIsExposed)
+	ctx.Step(`^the "([^"]*)" service has (\d+) ready endpoints$`, theServiceHasReadyEndpoints)
+	ctx.Step(`^the pod serving "([^"]*)" reports it is not ready$`, thePodServingReportsItIsNotReady)
+	ctx.Step(`^the "([^"]*)" service must have (\d+) ready endpoints$`, theServiceMustHaveReadyEndpoints)
+	ctx.Step(`^I send (\d+) requests to "([^"]*)"$`, iSendRequestsTo)
+	ctx.Step(`^all the responses status-code must be (\d+)$`, allTheResponsesStatuscodeMustBe)
+	ctx.Step(`^none of the responses must be served by the pod that is not ready$`, noneOfTheResponsesMustBeServedByThePodThatIsNotReady)
+
+	ctx.BeforeScenario(func(*godog.Scenario) {
+		state = tstate.New()
+		notReadyPod = ""
+		statusCodes = make(map[int]int)
+		pods = make(map[string]int)
+	})
+
+	ctx.AfterScenario(func(*messages.Pickle, error) {
+		// delete namespace an all the content
+		_ = kubernetes.DeleteNamespace(kubernetes.KubeClient, state.Namespace)
+	})
+}
+
+func anIngressResourceInANewRandomNamespace(spec *messages.PickleStepArgument_PickleDocString) error {
+	ns, err := kubernetes.NewNamespace(kubernetes.KubeClient)
+	if err != nil {
+		return err
+	}
+
+	state.Namespace = ns
+
+	ingress, err := kubernetes.IngressFromManifest(state.Namespace, spec.GetContent())
+	if err != nil {
+		return err
+	}
+
+	err = kubernetes.DeploymentsFromIngress(kubernetes.KubeClient, ingress)
+	if err != nil {
+		return err
+	}
+
+	err = kubernetes.NewIngress(kubernetes.KubeClient, state.Namespace, ingress)
+	if err != nil {
+		return err
+	}
+
+	state.IngressName = ingress.GetName()
+
+	return nil
+}
+
+func theIngressStatusShowsTheIPAddressOrFQDNWhereItIsExposed() error {
+	ingress, err := kubernetes.WaitForIngressAddress(kubernetes.KubeClient, state.Namespace, state.IngressName)
+	if err != nil {
+		return err
+	}
+
+	state.IPOrFQDN = ingress
+
+	return err
+}
+
+func theServiceHasReadyEndpoints(service string, replicas int) error {
+	return kubernetes.ScaleIngressBackendDeployment(kubernetes.KubeClient, state.Namespace, state.IngressName, service, replicas)
+}
+
+func thePodServingReportsItIsNotReady(rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return err
+	}
+
+	// only one pod must report it is not ready
+	err = state.CaptureControlRoundTrip("PUT", u.Scheme, u.Host, u.Path, "ready=false")
+	if err != nil {
+		return err
+	}
+
+	err = state.AssertStatusCode(200)
+	if err != nil {
+		return err
+	}
+
+	if state.CapturedRequest.Pod == "" {
+		return fmt.Errorf("expected the name of the pod that is not ready but the response does not contain it")
+	}
+
+	notReadyPod = state.CapturedRequest.Pod
+	return nil
+}
+
+func theServiceMustHaveReadyEndpoints(service string, endpoints int) error {
+	return kubernetes.WaitForServiceReadyEndpoints(kubernetes.KubeClient, state.Namespace, service, endpoints)
+}
+
+func iSendRequestsTo(totalRequest int, rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return err
+	}
+
+	for iteration := 1; iteration <= totalRequest; iteration++ {
+		err := state.CaptureRoundTrip("GET", u.Scheme, u.Host, u.Path, u.RawQuery)
+		if err != nil {
+			return err
+		}
+
+		statusCodes[state.CapturedResponse.StatusCode]++
+		// the requests sent to each address must not be served by the pod that is not ready
+		for _, capturedRequest := range state.CapturedRequests {
+			pods[capturedRequest.Pod]++
+		}
+	}
+
+	return nil
+}
+
+func allTheResponsesStatuscodeMustBe(statusCode int) error {
+	for code, count := range statusCodes {
+		if code != statusCode {
+			return fmt.Errorf("expected all the responses with status code %v but %v returned %v", statusCode, count, code)
+		}
+	}
+
+	return nil
+}
+
+func noneOfTheResponsesMustBeServedByThePodThatIsNotReady() error {
+	if count, ok := pods[notReadyPod]; ok {
+		return fmt.Errorf("expected no responses served by the pod %v that is not ready but %v were served by it", notReadyPod, count)
+	}
+
+	return nil
+}
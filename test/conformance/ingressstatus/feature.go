@@ -0,0 +1,289 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingressstatus
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+
+	"github.com/cucumber/godog"
+	"github.com/cucumber/messages-go/v10"
+
+	"sigs.k8s.io/ingress-controller-conformance/test/http"
+	"sigs.k8s.io/ingress-controller-conformance/test/kubernetes"
+	tstate "sigs.k8s.io/ingress-controller-conformance/test/state"
+)
+
+var (
+	state *tstate.Scenario
+
+	// statusAddresses entries of the status of the Ingress
+	statusAddresses []kubernetes.IngressAddress
+	// otherIngressName name of the second Ingress of the scenario
+	otherIngressName string
+
+	// capturedRequests and capturedResponses by location (host or host:port) of the Ingress status
+	capturedRequests  map[string]*http.CapturedRequest
+	capturedResponses map[string]*http.CapturedResponse
+)
+
+// IMPORTANT: Steps definitions are generated and should not be modified
+// by hand but rather through make codegen. DO NOT EDIT.
+
+// InitializeScenario configures the Feature to test
+func InitializeScenario(ctx *godog.ScenarioContext) {
+	ctx.Step(`^a new random namespace$`, aNewRandomNamespace)
+	ctx.Step(`^a self-signed TLS secret named "([^"]*)" for the "([^"]*)" hostname$`, aSelfsignedTLSSecretNamedForTheHostname)
+	ctx.Step(`^an Ingress resource$`, anIngressResource)
+	ctx.Step(`^The Ingress status shows the IP address or FQDN where it is exposed$`, theIngressStatusShowsTheIPAddressOrFQDNWhereItIsExposed)
+	ctx.Step(`^each address in the Ingress status must be a valid IP address or DNS name$`, eachAddressInTheIngressStatusMustBeAValidIPAddressOrDNSName)
+	ctx.Step(`^the ports in the Ingress status must be valid when present$`, thePortsInTheIngressStatusMustBeValidWhenPresent)
+	ctx.Step(`^I send a "([^"]*)" request to "([^"]*)" using every address in the Ingress status$`, iSendARequestToUsingEveryAddressInTheIngressStatus)
+	ctx.Step(`^all the responses status-code must be (\d+)$`, allTheResponsesStatuscodeMustBe)
+	ctx.Step(`^all the responses must be served by the "([^"]*)" service$`, allTheResponsesMustBeServedByTheService)
+	ctx.Step(`^the Ingress is updated adding the path "([^"]*)"$`, theIngressIsUpdatedAddingThePath)
+	ctx.Step(`^the addresses in the Ingress status must not change$`, theAddressesInTheIngressStatusMustNotChange)
+	ctx.Step(`^I send a "([^"]*)" request to "([^"]*)"$`, iSendARequestTo)
+	ctx.Step(`^the response status-code must be (\d+)$`, theResponseStatuscodeMustBe)
+	ctx.Step(`^the response must be served by the "([^"]*)" service$`, theResponseMustBeServedByTheService)
+	ctx.Step(`^another Ingress resource$`, anotherIngressResource)
+	ctx.Step(`^the addresses in the status of both Ingresses must be the same$`, theAddressesInTheStatusOfBothIngressesMustBeTheSame)
+
+	ctx.BeforeScenario(func(*godog.Scenario) {
+		state = tstate.New()
+		statusAddresses = nil
+		otherIngressName = ""
+		capturedRequests = make(map[string]*http.CapturedRequest)
+		capturedResponses = make(map[string]*http.CapturedResponse)
+	})
+
+	ctx.AfterScenario(func(*messages.Pickle, error) {
+		// delete namespace an all the content
+		_ = kubernetes.DeleteNamespace(kubernetes.KubeClient, state.Namespace)
+	})
+}
+
+func aNewRandomNamespace() error {
+	ns, err := kubernetes.NewNamespace(kubernetes.KubeClient)
+	if err != nil {
+		return err
+	}
+
+	state.Namespace = ns
+	return nil
+}
+
+func aSelfsignedTLSSecretNamedForTheHostname(secretName string, host string) error {
+	err := kubernetes.NewSelfSignedSecret(kubernetes.KubeClient, state.Namespace, secretName, []string{host})
+	if err != nil {
+		return err
+	}
+
+	state.SecretName = secretName
+
+	return nil
+}
+
+func anIngressResource(spec *messages.PickleStepArgument_PickleDocString) error {
+	ingress, err := kubernetes.IngressFromManifest(state.Namespace, spec.GetContent())
+	if err != nil {
+		return err
+	}
+
+	err = kubernetes.DeploymentsFromIngress(kubernetes.KubeClient, ingress)
+	if err != nil {
+		return err
+	}
+
+	err = kubernetes.NewIngress(kubernetes.KubeClient, state.Namespace, ingress)
+	if err != nil {
+		return err
+	}
+
+	state.IngressName = ingress.GetName()
+
+	return nil
+}
+
+func theIngressStatusShowsTheIPAddressOrFQDNWhereItIsExposed() error {
+	addresses, err := kubernetes.WaitForIngressStatusAddresses(kubernetes.KubeClient, state.Namespace, state.IngressName)
+	if err != nil {
+		return err
+	}
+
+	statusAddresses = addresses
+	state.IPOrFQDN = kubernetes.SelectIngressAddress(addressList(addresses), kubernetes.IngressAddressFamily)
+	if state.IPOrFQDN == "" {
+		return fmt.Errorf("the Ingress status does not contain an address of the %v family", kubernetes.IngressAddressFamily)
+	}
+
+	return nil
+}
+
+func eachAddressInTheIngressStatusMustBeAValidIPAddressOrDNSName() error {
+	for _, address := range statusAddresses {
+		err := address.Validate()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func thePortsInTheIngressStatusMustBeValidWhenPresent() error {
+	for _, address := range statusAddresses {
+		err := address.ValidatePorts()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func iSendARequestToUsingEveryAddressInTheIngressStatus(method string, rawURL string) error {
+	if http.AddressOverrideEnabled() {
+		return fmt.Errorf("every address in the Ingress status cannot be verified sending all the requests to the same address " +
+			"(--ingress-http-address or --ingress-https-address). Use --ingress-address-map instead")
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return err
+	}
+
+	for _, address := range statusAddresses {
+		for location, scheme := range addressLocations(address, u.Scheme) {
+			capturedRequest, capturedResponse, err := http.CaptureRoundTrip(method, scheme, u.Host, u.Path, u.RawQuery, location)
+			if err != nil {
+				return fmt.Errorf("sending request to %v://%v: %w", scheme, location, err)
+			}
+
+			capturedRequests[location] = capturedRequest
+			capturedResponses[location] = capturedResponse
+		}
+	}
+
+	return nil
+}
+
+func allTheResponsesStatuscodeMustBe(statusCode int) error {
+	for address, capturedResponse := range capturedResponses {
+		if capturedResponse.StatusCode != statusCode {
+			return fmt.Errorf("expected status code %v from address %v but %v was returned", statusCode, address, capturedResponse.StatusCode)
+		}
+	}
+
+	return nil
+}
+
+func allTheResponsesMustBeServedByTheService(service string) error {
+	for address, capturedRequest := range capturedRequests {
+		if capturedRequest.Service != service {
+			return fmt.Errorf("expected the request to address %v to be served by %v but it was served by %v", address, service, capturedRequest.Service)
+		}
+	}
+
+	return nil
+}
+
+func theIngressIsUpdatedAddingThePath(path string) error {
+	return kubernetes.AddIngressPath(kubernetes.KubeClient, state.Namespace, state.IngressName, path)
+}
+
+func theAddressesInTheIngressStatusMustNotChange() error {
+	return kubernetes.WaitForIngressStatusStability(kubernetes.KubeClient, state.Namespace, state.IngressName, statusAddresses)
+}
+
+func iSendARequestTo(method string, rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return err
+	}
+	return state.CaptureRoundTrip(method, u.Scheme, u.Host, u.Path, u.RawQuery)
+}
+
+func theResponseStatuscodeMustBe(statusCode int) error {
+	return state.AssertStatusCode(statusCode)
+}
+
+func theResponseMustBeServedByTheService(service string) error {
+	return state.AssertServedBy(service)
+}
+
+func anotherIngressResource(spec *messages.PickleStepArgument_PickleDocString) error {
+	ingress, err := kubernetes.IngressFromManifest(state.Namespace, spec.GetContent())
+	if err != nil {
+		return err
+	}
+
+	err = kubernetes.DeploymentsFromIngress(kubernetes.KubeClient, ingress)
+	if err != nil {
+		return err
+	}
+
+	err = kubernetes.NewIngress(kubernetes.KubeClient, state.Namespace, ingress)
+	if err != nil {
+		return err
+	}
+
+	otherIngressName = ingress.GetName()
+
+	return nil
+}
+
+func theAddressesInTheStatusOfBothIngressesMustBeTheSame() error {
+	return kubernetes.WaitForSameIngressStatusAddresses(kubernetes.KubeClient, state.Namespace, otherIngressName, statusAddresses)
+}
+
+// addressLocations returns the scheme used to send requests to each location (host or host:port)
+// of the entry. Requests are sent to every TCP port without error, using HTTPS for the port 443.
+// Entries without ports use the scheme of the request and its default port.
+func addressLocations(address kubernetes.IngressAddress, scheme string) map[string]string {
+	locations := map[string]string{}
+	for _, port := range address.Ports {
+		if port.Protocol != "TCP" || port.Error != nil {
+			continue
+		}
+
+		portScheme := "http"
+		if port.Port == 443 {
+			portScheme = "https"
+		}
+
+		locations[net.JoinHostPort(address.Address(), strconv.Itoa(int(port.Port)))] = portScheme
+	}
+
+	if len(locations) == 0 {
+		locations[address.Address()] = scheme
+	}
+
+	return locations
+}
+
+// addressList returns the IP addresses and hostnames of the entries
+func addressList(addresses []kubernetes.IngressAddress) []string {
+	var list []string
+	for _, address := range addresses {
+		list = append(list, address.Address())
+	}
+
+	return list
+}
@@ -0,0 +1,158 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package invalidtlssecrets
+
+import (
+	"net/url"
+	"time"
+
+	"github.com/cucumber/godog"
+	"github.com/cucumber/messages-go/v10"
+
+	"sigs.k8s.io/ingress-controller-conformance/test/kubernetes"
+	"sigs.k8s.io/ingress-controller-conformance/test/report"
+	tstate "sigs.k8s.io/ingress-controller-conformance/test/state"
+)
+
+var (
+	state *tstate.Scenario
+)
+
+// IMPORTANT: Steps definitions are generated and should not be modified
+// by hand but rather through make codegen. DO NOT EDIT.
+
+// InitializeScenario configures the Feature to test
+func InitializeScenario(ctx *godog.ScenarioContext) {
+	ctx.Step(`^a new random namespace$`, aNewRandomNamespace)
+	ctx.Step(`^a self-signed TLS secret named "([^"]*)" for the "([^"]*)" hostname$`, aSelfsignedTLSSecretNamedForTheHostname)
+	ctx.Step(`^a "([^"]*)" TLS secret named "([^"]*)" for the "([^"]*)" hostname$`, aTLSSecretNamedForTheHostname)
+	ctx.Step(`^an Ingress resource$`, anIngressResource)
+	ctx.Step(`^The Ingress status shows the IP address or FQDN where it is exposed$`, theIngressStatusShowsTheIPAddressOrFQDNWhereItIsExposed)
+	ctx.Step(`^I send a "([^"]*)" request to "([^"]*)" that may fail$`, iSendARequestToThatMayFail)
+	ctx.Step(`^the response for the "([^"]*)" host with a "([^"]*)" secret in the Ingress is recorded$`, theResponseForTheHostWithASecretInTheIngressIsRecorded)
+	ctx.Step(`^the response for the "([^"]*)" host over HTTP with a "([^"]*)" secret in the Ingress is recorded$`, theResponseForTheHostOverHTTPWithASecretInTheIngressIsRecorded)
+
+	ctx.BeforeScenario(func(*godog.Scenario) {
+		state = tstate.New()
+	})
+
+	ctx.AfterScenario(func(*messages.Pickle, error) {
+		// delete namespace an all the content
+		_ = kubernetes.DeleteNamespace(kubernetes.KubeClient, state.Namespace)
+	})
+}
+
+func aNewRandomNamespace() error {
+	ns, err := kubernetes.NewNamespace(kubernetes.KubeClient)
+	if err != nil {
+		return err
+	}
+
+	state.Namespace = ns
+	return nil
+}
+
+func aSelfsignedTLSSecretNamedForTheHostname(secretName string, host string) error {
+	err := kubernetes.NewSelfSignedSecret(kubernetes.KubeClient, state.Namespace, secretName, []string{host})
+	if err != nil {
+		return err
+	}
+
+	state.SecretName = secretName
+
+	return nil
+}
+
+func aTLSSecretNamedForTheHostname(variant string, secretName string, host string) error {
+	return kubernetes.NewTLSSecret(kubernetes.KubeClient, state.Namespace, secretName, []string{host}, kubernetes.TLSSecretVariant(variant))
+}
+
+func anIngressResource(spec *messages.PickleStepArgument_PickleDocString) error {
+	ingress, err := kubernetes.IngressFromManifest(state.Namespace, spec.GetContent())
+	if err != nil {
+		return err
+	}
+
+	err = kubernetes.DeploymentsFromIngress(kubernetes.KubeClient, ingress)
+	if err != nil {
+		return err
+	}
+
+	err = kubernetes.NewIngress(kubernetes.KubeClient, state.Namespace, ingress)
+	if err != nil {
+		return err
+	}
+
+	state.IngressName = ingress.GetName()
+
+	return nil
+}
+
+func theIngressStatusShowsTheIPAddressOrFQDNWhereItIsExposed() error {
+	ingress, err := kubernetes.WaitForIngressAddress(kubernetes.KubeClient, state.Namespace, state.IngressName)
+	if err != nil {
+		return err
+	}
+
+	state.IPOrFQDN = ingress
+
+	return err
+}
+
+func iSendARequestToThatMayFail(method string, rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return err
+	}
+
+	state.CaptureRoundTripThatMayFail(method, u.Scheme, u.Host, u.Path, u.RawQuery)
+	return nil
+}
+
+func theResponseForTheHostWithASecretInTheIngressIsRecorded(host string, variant string) error {
+	if !recordResponse(host, variant) {
+		return nil
+	}
+
+	certificate := state.CapturedResponse.Certificate
+	report.Observe("dnsNames", certificate.DNSNames)
+	report.Observe("validForHost", certificate.VerifyHostname(host) == nil)
+	report.Observe("expired", time.Now().After(certificate.NotAfter))
+
+	validCertificate, err := kubernetes.SecretCertificate(kubernetes.KubeClient, state.Namespace, state.SecretName)
+	if err != nil {
+		return err
+	}
+
+	report.Observe("validSecretCertificate", certificate.Equal(validCertificate))
+
+	return nil
+}
+
+func theResponseForTheHostOverHTTPWithASecretInTheIngressIsRecorded(host string, variant string) error {
+	recordResponse(host, variant)
+	return nil
+}
+
+// recordResponse records the result of the last request that may fail.
+// It returns false if the request failed.
+func recordResponse(host string, variant string) bool {
+	report.Observe("host", host)
+	report.Observe("variant", variant)
+
+	return state.ObserveResponse()
+}
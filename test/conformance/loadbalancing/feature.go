@@ -18,14 +18,16 @@ package loadbalancing
 
 import (
 	"fmt"
+	"math"
 	"net/url"
 
 	"github.com/cucumber/godog"
 	"github.com/cucumber/messages-go/v10"
 	"k8s.io/apimachinery/pkg/util/sets"
 
-	"sigs.k8s.io/ingress-controller-conformance/test/http"
+	"sigs.k8s.io/ingress-controller-conformance/test/distribution"
 	"sigs.k8s.io/ingress-controller-conformance/test/kubernetes"
+	"sigs.k8s.io/ingress-controller-conformance/test/report"
 	tstate "sigs.k8s.io/ingress-controller-conformance/test/state"
 )
 
@@ -33,6 +35,11 @@ var (
 	state *tstate.Scenario
 
 	resultStatus map[int]sets.String
+
+	// podRequests number of requests served by each pod
+	podRequests distribution.Histogram
+	// expectedPods number of replicas of the backend deployment
+	expectedPods int
 )
 
 // IMPORTANT: Steps definitions are generated and should not be modified
@@ -46,10 +53,15 @@ func InitializeScenario(ctx *godog.ScenarioContext) {
 	ctx.Step(`^The backend deployment "([^"]*)" for the ingress resource is scaled to (\d+)$`, theBackendDeploymentForTheIngressResourceIsScaledTo)
 	ctx.Step(`^I send (\d+) requests to "([^"]*)"$`, iSendRequestsTo)
 	ctx.Step(`^all the responses status-code must be (\d+) and the response body should contain the IP address of (\d+) different Kubernetes pods$`, allTheResponsesStatuscodeMustBeAndTheResponseBodyShouldContainTheIPAddressOfDifferentKubernetesPods)
+	ctx.Step(`^the number of requests served by each pod is recorded$`, theNumberOfRequestsServedByEachPodIsRecorded)
+	ctx.Step(`^the ratio between the pods serving the most and the fewest requests must not exceed the configured maximum$`, theRatioBetweenThePodsServingTheMostAndTheFewestRequestsMustNotExceedTheConfiguredMaximum)
+	ctx.Step(`^the result of the chi-square uniformity test is recorded$`, theResultOfTheChisquareUniformityTestIsRecorded)
 
 	ctx.BeforeScenario(func(*godog.Scenario) {
 		state = tstate.New()
 		resultStatus = make(map[int]sets.String, 0)
+		podRequests = make(distribution.Histogram)
+		expectedPods = 0
 	})
 
 	ctx.AfterScenario(func(*messages.Pickle, error) {
@@ -106,16 +118,21 @@ func iSendRequestsTo(totalRequest int, rawURL string) error {
 	}
 
 	for iteration := 1; iteration <= totalRequest; iteration++ {
-		capturedRequest, capturedResponse, err := http.CaptureRoundTrip("GET", u.Scheme, u.Host, u.Path, state.IPOrFQDN)
+		err := state.CaptureRoundTrip("GET", u.Scheme, u.Host, u.Path, u.RawQuery)
 		if err != nil {
 			return err
 		}
 
-		if resultStatus[capturedResponse.StatusCode] == nil {
-			resultStatus[capturedResponse.StatusCode] = sets.NewString()
+		statusCode := state.CapturedResponse.StatusCode
+		if resultStatus[statusCode] == nil {
+			resultStatus[statusCode] = sets.NewString()
 		}
 
-		resultStatus[capturedResponse.StatusCode].Insert(capturedRequest.Pod)
+		resultStatus[statusCode].Insert(state.CapturedRequest.Pod)
+
+		if state.CapturedRequest.Pod != "" {
+			podRequests.Add(state.CapturedRequest.Pod)
+		}
 	}
 
 	return nil
@@ -135,5 +152,39 @@ func allTheResponsesStatuscodeMustBeAndTheResponseBodyShouldContainTheIPAddressO
 }
 
 func theBackendDeploymentForTheIngressResourceIsScaledTo(deployment string, replicas int) error {
+	expectedPods = replicas
 	return kubernetes.ScaleIngressBackendDeployment(kubernetes.KubeClient, state.Namespace, state.IngressName, deployment, replicas)
 }
+
+func theNumberOfRequestsServedByEachPodIsRecorded() error {
+	report.Observe("requests", podRequests.Total())
+	report.Observe("pods", podRequests)
+
+	if len(podRequests) == 0 {
+		return nil
+	}
+
+	// the ratio is infinite when some pods did not serve requests
+	if ratio := podRequests.Ratio(expectedPods); !math.IsInf(ratio, 1) {
+		report.Observe("ratio", ratio)
+	}
+
+	return nil
+}
+
+func theRatioBetweenThePodsServingTheMostAndTheFewestRequestsMustNotExceedTheConfiguredMaximum() error {
+	return podRequests.AssertRatio(expectedPods, distribution.MaxRatio)
+}
+
+func theResultOfTheChisquareUniformityTestIsRecorded() error {
+	if len(podRequests) == 0 {
+		return nil
+	}
+
+	statistic, pValue := podRequests.ChiSquare(expectedPods)
+	report.Observe("chiSquare", statistic)
+	report.Observe("pValue", pValue)
+	report.Observe("uniform", podRequests.AssertUniform(expectedPods, distribution.Significance) == nil)
+
+	return nil
+}
@@ -96,7 +96,7 @@ func iSendARequestTo(method string, rawURL string) error {
 	if err != nil {
 		return err
 	}
-	return state.CaptureRoundTrip(method, u.Scheme, u.Host, u.Path)
+	return state.CaptureRoundTrip(method, u.Scheme, u.Host, u.Path, u.RawQuery)
 }
 
 func theResponseStatuscodeMustBe(statusCode int) error {
@@ -0,0 +1,159 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestbody
+
+import (
+	"net/url"
+
+	"github.com/cucumber/godog"
+	"github.com/cucumber/messages-go/v10"
+
+	"sigs.k8s.io/ingress-controller-conformance/test/http"
+	"sigs.k8s.io/ingress-controller-conformance/test/kubernetes"
+	tstate "sigs.k8s.io/ingress-controller-conformance/test/state"
+)
+
+var (
+	state *tstate.Scenario
+)
+
+// IMPORTANT: Steps definitions are generated and should not be modified
+// by hand but rather through make codegen. DO NOT EDIT.
+
+// InitializeScenario configures the Feature to test
+func InitializeScenario(ctx *godog.ScenarioContext) {
+	ctx.Step(`^a new random namespace$`, aNewRandomNamespace)
+	ctx.Step(`^an Ingress resource named "([^"]*)" with this spec:$`, anIngressResourceNamedWithThisSpec)
+	ctx.Step(`^The Ingress status shows the IP address or FQDN where it is exposed$`, theIngressStatusShowsTheIPAddressOrFQDNWhereItIsExposed)
+	ctx.Step(`^I send a "([^"]*)" request to "([^"]*)" with a body of (\d+) bytes using "([^"]*)" transfer$`, iSendARequestToWithABodyOfBytesUsingTransfer)
+	ctx.Step(`^the response status-code must be (\d+)$`, theResponseStatuscodeMustBe)
+	ctx.Step(`^the response must be served by the "([^"]*)" service$`, theResponseMustBeServedByTheService)
+	ctx.Step(`^the request method must be "([^"]*)"$`, theRequestMethodMustBe)
+	ctx.Step(`^the request body must be received without modifications$`, theRequestBodyMustBeReceivedWithoutModifications)
+	ctx.Step(`^I send a "([^"]*)" request to "([^"]*)" with a body of (\d+) bytes using "([^"]*)" transfer that may fail$`, iSendARequestToWithABodyOfBytesUsingTransferThatMayFail)
+	ctx.Step(`^the response to the request with a large body is recorded$`, theResponseToTheRequestWithALargeBodyIsRecorded)
+	ctx.Step(`^the request body must be received without modifications when the request is accepted$`, theRequestBodyMustBeReceivedWithoutModificationsWhenTheRequestIsAccepted)
+
+	ctx.BeforeScenario(func(*godog.Scenario) {
+		state = tstate.New()
+	})
+
+	ctx.AfterScenario(func(*messages.Pickle, error) {
+		// delete namespace an all the content
+		_ = kubernetes.DeleteNamespace(kubernetes.KubeClient, state.Namespace)
+	})
+}
+
+func aNewRandomNamespace() error {
+	ns, err := kubernetes.NewNamespace(kubernetes.KubeClient)
+	if err != nil {
+		return err
+	}
+
+	state.Namespace = ns
+	return nil
+}
+
+func anIngressResourceNamedWithThisSpec(name string, spec *messages.PickleStepArgument_PickleDocString) error {
+	ingress, err := kubernetes.IngressFromSpec(name, state.Namespace, spec.GetContent())
+	if err != nil {
+		return err
+	}
+
+	err = kubernetes.DeploymentsFromIngress(kubernetes.KubeClient, ingress)
+	if err != nil {
+		return err
+	}
+
+	err = kubernetes.NewIngress(kubernetes.KubeClient, state.Namespace, ingress)
+	if err != nil {
+		return err
+	}
+
+	state.IngressName = name
+
+	return nil
+}
+
+func theIngressStatusShowsTheIPAddressOrFQDNWhereItIsExposed() error {
+	ingress, err := kubernetes.WaitForIngressAddress(kubernetes.KubeClient, state.Namespace, state.IngressName)
+	if err != nil {
+		return err
+	}
+
+	state.IPOrFQDN = ingress
+	return err
+}
+
+func iSendARequestToWithABodyOfBytesUsingTransfer(method string, rawURL string, size int, transfer string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return err
+	}
+
+	body, err := http.NewRequestBody(int64(size), transfer)
+	if err != nil {
+		return err
+	}
+
+	return state.CaptureRoundTripWithBody(method, u.Scheme, u.Host, u.Path, u.RawQuery, body)
+}
+
+func theResponseStatuscodeMustBe(statusCode int) error {
+	return state.AssertStatusCode(statusCode)
+}
+
+func theResponseMustBeServedByTheService(service string) error {
+	return state.AssertServedBy(service)
+}
+
+func theRequestMethodMustBe(method string) error {
+	return state.AssertMethod(method)
+}
+
+func theRequestBodyMustBeReceivedWithoutModifications() error {
+	return state.AssertRequestBody()
+}
+
+func iSendARequestToWithABodyOfBytesUsingTransferThatMayFail(method string, rawURL string, size int, transfer string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return err
+	}
+
+	body, err := http.NewRequestBody(int64(size), transfer)
+	if err != nil {
+		return err
+	}
+
+	state.CaptureRoundTripWithBodyThatMayFail(method, u.Scheme, u.Host, u.Path, u.RawQuery, body)
+	return nil
+}
+
+func theResponseToTheRequestWithALargeBodyIsRecorded() error {
+	state.ObserveResponse()
+	return nil
+}
+
+func theRequestBodyMustBeReceivedWithoutModificationsWhenTheRequestIsAccepted() error {
+	// the ingress controller may reject the request or close the connection
+	if state.RequestError != nil || state.CapturedResponse.StatusCode < 200 || state.CapturedResponse.StatusCode > 299 {
+		return nil
+	}
+
+	return state.AssertRequestBody()
+}
@@ -0,0 +1,155 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package responsestreaming
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/cucumber/godog"
+	"github.com/cucumber/messages-go/v10"
+
+	"sigs.k8s.io/ingress-controller-conformance/test/http"
+	"sigs.k8s.io/ingress-controller-conformance/test/kubernetes"
+	"sigs.k8s.io/ingress-controller-conformance/test/report"
+	tstate "sigs.k8s.io/ingress-controller-conformance/test/state"
+)
+
+var (
+	state *tstate.Scenario
+
+	// streamInterval time between the events of the last stream requested
+	streamInterval time.Duration
+)
+
+// IMPORTANT: Steps definitions are generated and should not be modified
+// by hand but rather through make codegen. DO NOT EDIT.
+
+// InitializeScenario configures the Feature to test
+func InitializeScenario(ctx *godog.ScenarioContext) {
+	ctx.Step(`^a new random namespace$`, aNewRandomNamespace)
+	ctx.Step(`^a self-signed TLS secret named "([^"]*)" for the "([^"]*)" hostname$`, aSelfsignedTLSSecretNamedForTheHostname)
+	ctx.Step(`^an Ingress resource$`, anIngressResource)
+	ctx.Step(`^The Ingress status shows the IP address or FQDN where it is exposed$`, theIngressStatusShowsTheIPAddressOrFQDNWhereItIsExposed)
+	ctx.Step(`^I request a stream of (\d+) events sent every (\d+) milliseconds from "([^"]*)"$`, iRequestAStreamOfEventsSentEveryMillisecondsFrom)
+	ctx.Step(`^the stream status-code must be (\d+)$`, theStreamStatuscodeMustBe)
+	ctx.Step(`^the stream must contain (\d+) events$`, theStreamMustContainEvents)
+	ctx.Step(`^the first event must be received at least (\d+) milliseconds before the stream finishes$`, theFirstEventMustBeReceivedAtLeastMillisecondsBeforeTheStreamFinishes)
+	ctx.Step(`^the time elapsed between the first and the last piece of the stream is recorded$`, theTimeElapsedBetweenTheFirstAndTheLastPieceOfTheStreamIsRecorded)
+
+	ctx.BeforeScenario(func(*godog.Scenario) {
+		state = tstate.New()
+	})
+
+	ctx.AfterScenario(func(*messages.Pickle, error) {
+		// delete namespace an all the content
+		_ = kubernetes.DeleteNamespace(kubernetes.KubeClient, state.Namespace)
+	})
+}
+
+func aNewRandomNamespace() error {
+	ns, err := kubernetes.NewNamespace(kubernetes.KubeClient)
+	if err != nil {
+		return err
+	}
+
+	state.Namespace = ns
+	return nil
+}
+
+func aSelfsignedTLSSecretNamedForTheHostname(secretName string, host string) error {
+	err := kubernetes.NewSelfSignedSecret(kubernetes.KubeClient, state.Namespace, secretName, []string{host})
+	if err != nil {
+		return err
+	}
+
+	state.SecretName = secretName
+
+	return nil
+}
+
+func anIngressResource(spec *messages.PickleStepArgument_PickleDocString) error {
+	ingress, err := kubernetes.IngressFromManifest(state.Namespace, spec.GetContent())
+	if err != nil {
+		return err
+	}
+
+	err = kubernetes.DeploymentsFromIngress(kubernetes.KubeClient, ingress)
+	if err != nil {
+		return err
+	}
+
+	err = kubernetes.NewIngress(kubernetes.KubeClient, state.Namespace, ingress)
+	if err != nil {
+		return err
+	}
+
+	state.IngressName = ingress.GetName()
+
+	return nil
+}
+
+func theIngressStatusShowsTheIPAddressOrFQDNWhereItIsExposed() error {
+	ingress, err := kubernetes.WaitForIngressAddress(kubernetes.KubeClient, state.Namespace, state.IngressName)
+	if err != nil {
+		return err
+	}
+
+	state.IPOrFQDN = ingress
+
+	return err
+}
+
+func iRequestAStreamOfEventsSentEveryMillisecondsFrom(events int, interval int, rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return err
+	}
+
+	streamInterval = time.Duration(interval) * time.Millisecond
+
+	query := fmt.Sprintf("events=%v&interval=%vms", events, interval)
+	return state.CaptureStream("GET", u.Scheme, u.Host, u.Path, query)
+}
+
+func theStreamStatuscodeMustBe(statusCode int) error {
+	return state.AssertStreamStatusCode(statusCode)
+}
+
+func theStreamMustContainEvents(events int) error {
+	return state.AssertStreamEvents(events)
+}
+
+func theFirstEventMustBeReceivedAtLeastMillisecondsBeforeTheStreamFinishes(milliseconds int) error {
+	return state.AssertStreamNotBuffered(time.Duration(milliseconds)*time.Millisecond - http.StreamTimingTolerance)
+}
+
+func theTimeElapsedBetweenTheFirstAndTheLastPieceOfTheStreamIsRecorded() error {
+	chunks := state.CapturedStream.Chunks
+	report.Observe("pieces", len(chunks))
+	if len(chunks) == 0 {
+		return nil
+	}
+
+	elapsed := chunks[len(chunks)-1].Received - chunks[0].Received
+	report.Observe("elapsed", elapsed.Round(time.Millisecond).String())
+	// an unbuffered stream receives the last event at least one interval after the first one
+	report.Observe("buffered", elapsed < streamInterval)
+
+	return nil
+}
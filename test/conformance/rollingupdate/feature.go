@@ -0,0 +1,137 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rollingupdate
+
+import (
+	"net/url"
+	"time"
+
+	"github.com/cucumber/godog"
+	"github.com/cucumber/messages-go/v10"
+
+	"sigs.k8s.io/ingress-controller-conformance/test/http"
+	"sigs.k8s.io/ingress-controller-conformance/test/kubernetes"
+	"sigs.k8s.io/ingress-controller-conformance/test/report"
+	tstate "sigs.k8s.io/ingress-controller-conformance/test/state"
+)
+
+var (
+	state *tstate.Scenario
+)
+
+// IMPORTANT: Steps definitions are generated and should not be modified
+// by hand but rather through make codegen. DO NOT EDIT.
+
+// InitializeScenario configures the Feature to test
+func InitializeScenario(ctx *godog.ScenarioContext) {
+	ctx.Step(`^an Ingress resource in a new random namespace$`, anIngressResourceInANewRandomNamespace)
+	ctx.Step(`^The Ingress status shows the IP address or FQDN where it is exposed$`, theIngressStatusShowsTheIPAddressOrFQDNWhereItIsExposed)
+	ctx.Step(`^the "([^"]*)" service has (\d+) ready endpoints$`, theServiceHasReadyEndpoints)
+	ctx.Step(`^I start sending "([^"]*)" requests to "([^"]*)" using (\d+) concurrent clients$`, iStartSendingRequestsToUsingConcurrentClients)
+	ctx.Step(`^a rolling update of the "([^"]*)" service is performed$`, aRollingUpdateOfTheServiceIsPerformed)
+	ctx.Step(`^I stop sending requests$`, iStopSendingRequests)
+	ctx.Step(`^the results of the requests sent during the rolling update are recorded$`, theResultsOfTheRequestsSentDuringTheRollingUpdateAreRecorded)
+	ctx.Step(`^the error rate of the requests must not exceed the configured maximum$`, theErrorRateOfTheRequestsMustNotExceedTheConfiguredMaximum)
+
+	ctx.BeforeScenario(func(*godog.Scenario) {
+		state = tstate.New()
+	})
+
+	ctx.AfterScenario(func(*messages.Pickle, error) {
+		// stop requests sent in the background if a step failed
+		_ = state.StopLoad()
+
+		// delete namespace an all the content
+		_ = kubernetes.DeleteNamespace(kubernetes.KubeClient, state.Namespace)
+	})
+}
+
+func anIngressResourceInANewRandomNamespace(spec *messages.PickleStepArgument_PickleDocString) error {
+	ns, err := kubernetes.NewNamespace(kubernetes.KubeClient)
+	if err != nil {
+		return err
+	}
+
+	state.Namespace = ns
+
+	ingress, err := kubernetes.IngressFromManifest(state.Namespace, spec.GetContent())
+	if err != nil {
+		return err
+	}
+
+	err = kubernetes.DeploymentsFromIngress(kubernetes.KubeClient, ingress)
+	if err != nil {
+		return err
+	}
+
+	err = kubernetes.NewIngress(kubernetes.KubeClient, state.Namespace, ingress)
+	if err != nil {
+		return err
+	}
+
+	state.IngressName = ingress.GetName()
+
+	return nil
+}
+
+func theIngressStatusShowsTheIPAddressOrFQDNWhereItIsExposed() error {
+	ingress, err := kubernetes.WaitForIngressAddress(kubernetes.KubeClient, state.Namespace, state.IngressName)
+	if err != nil {
+		return err
+	}
+
+	state.IPOrFQDN = ingress
+
+	return err
+}
+
+func theServiceHasReadyEndpoints(service string, replicas int) error {
+	return kubernetes.ScaleIngressBackendDeployment(kubernetes.KubeClient, state.Namespace, state.IngressName, service, replicas)
+}
+
+func iStartSendingRequestsToUsingConcurrentClients(method string, rawURL string, concurrency int) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return err
+	}
+
+	return state.StartLoad(method, u.Scheme, u.Host, u.Path, concurrency)
+}
+
+func aRollingUpdateOfTheServiceIsPerformed(service string) error {
+	return kubernetes.RolloutIngressBackendDeployment(kubernetes.KubeClient, state.Namespace, state.IngressName, service)
+}
+
+func iStopSendingRequests() error {
+	return state.StopLoad()
+}
+
+func theResultsOfTheRequestsSentDuringTheRollingUpdateAreRecorded() error {
+	report.Observe("requests", state.LoadResults.Requests)
+	report.Observe("failures", state.LoadResults.Failures)
+	report.Observe("errorRate", state.LoadResults.ErrorRate())
+	report.Observe("statusCodes", state.LoadResults.StatusCodes)
+	report.Observe("errors", state.LoadResults.Errors)
+	report.Observe("pods", state.LoadResults.Pods)
+	report.Observe("duration", state.LoadResults.Duration.Round(time.Second).String())
+
+	return nil
+}
+
+func theErrorRateOfTheRequestsMustNotExceedTheConfiguredMaximum() error {
+	return state.AssertLoadErrorRate(http.MaxLoadErrorRate)
+}
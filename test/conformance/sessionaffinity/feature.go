@@ -0,0 +1,152 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sessionaffinity
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/cucumber/godog"
+	"github.com/cucumber/messages-go/v10"
+	corev1 "k8s.io/api/core/v1"
+
+	"sigs.k8s.io/ingress-controller-conformance/test/distribution"
+	"sigs.k8s.io/ingress-controller-conformance/test/kubernetes"
+	"sigs.k8s.io/ingress-controller-conformance/test/report"
+	tstate "sigs.k8s.io/ingress-controller-conformance/test/state"
+)
+
+var (
+	state *tstate.Scenario
+
+	statusCodes map[int]int
+	// podRequests number of requests served by each pod
+	podRequests distribution.Histogram
+)
+
+// IMPORTANT: Steps definitions are generated and should not be modified
+// by hand but rather through make codegen. DO NOT EDIT.
+
+// InitializeScenario configures the Feature to test
+func InitializeScenario(ctx *godog.ScenarioContext) {
+	ctx.Step(`^an Ingress resource in a new random namespace with backend services using "([^"]*)" session affinity$`, anIngressResourceInANewRandomNamespaceWithBackendServicesUsingSessionAffinity)
+	ctx.Step(`^The Ingress status shows the IP address or FQDN where it is exposed$`, theIngressStatusShowsTheIPAddressOrFQDNWhereItIsExposed)
+	ctx.Step(`^the "([^"]*)" service has (\d+) ready endpoints$`, theServiceHasReadyEndpoints)
+	ctx.Step(`^I send (\d+) requests to "([^"]*)"$`, iSendRequestsTo)
+	ctx.Step(`^all the responses status-code must be (\d+)$`, allTheResponsesStatuscodeMustBe)
+	ctx.Step(`^the pods serving the requests with "([^"]*)" session affinity are recorded$`, thePodsServingTheRequestsWithSessionAffinityAreRecorded)
+
+	ctx.BeforeScenario(func(*godog.Scenario) {
+		state = tstate.New()
+		statusCodes = make(map[int]int)
+		podRequests = make(distribution.Histogram)
+	})
+
+	ctx.AfterScenario(func(*messages.Pickle, error) {
+		// delete namespace an all the content
+		_ = kubernetes.DeleteNamespace(kubernetes.KubeClient, state.Namespace)
+	})
+}
+
+func anIngressResourceInANewRandomNamespaceWithBackendServicesUsingSessionAffinity(affinity string, spec *messages.PickleStepArgument_PickleDocString) error {
+	ns, err := kubernetes.NewNamespace(kubernetes.KubeClient)
+	if err != nil {
+		return err
+	}
+
+	state.Namespace = ns
+
+	ingress, err := kubernetes.IngressFromManifest(state.Namespace, spec.GetContent())
+	if err != nil {
+		return err
+	}
+
+	options := kubernetes.BackendOptions{
+		SessionAffinity: corev1.ServiceAffinity(affinity),
+	}
+
+	err = kubernetes.DeploymentsFromIngressWithOptions(kubernetes.KubeClient, ingress, options)
+	if err != nil {
+		return err
+	}
+
+	err = kubernetes.NewIngress(kubernetes.KubeClient, state.Namespace, ingress)
+	if err != nil {
+		return err
+	}
+
+	state.IngressName = ingress.GetName()
+
+	return nil
+}
+
+func theIngressStatusShowsTheIPAddressOrFQDNWhereItIsExposed() error {
+	ingress, err := kubernetes.WaitForIngressAddress(kubernetes.KubeClient, state.Namespace, state.IngressName)
+	if err != nil {
+		return err
+	}
+
+	state.IPOrFQDN = ingress
+
+	return err
+}
+
+func theServiceHasReadyEndpoints(service string, replicas int) error {
+	return kubernetes.ScaleIngressBackendDeployment(kubernetes.KubeClient, state.Namespace, state.IngressName, service, replicas)
+}
+
+func iSendRequestsTo(totalRequest int, rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return err
+	}
+
+	for iteration := 1; iteration <= totalRequest; iteration++ {
+		err := state.CaptureRoundTrip("GET", u.Scheme, u.Host, u.Path, u.RawQuery)
+		if err != nil {
+			return err
+		}
+
+		statusCodes[state.CapturedResponse.StatusCode]++
+
+		if state.CapturedRequest.Pod != "" {
+			podRequests.Add(state.CapturedRequest.Pod)
+		}
+	}
+
+	return nil
+}
+
+func allTheResponsesStatuscodeMustBe(statusCode int) error {
+	for code, count := range statusCodes {
+		if code != statusCode {
+			return fmt.Errorf("expected all the responses with status code %v but %v returned %v", statusCode, count, code)
+		}
+	}
+
+	return nil
+}
+
+func thePodsServingTheRequestsWithSessionAffinityAreRecorded(affinity string) error {
+	report.Observe("sessionAffinity", affinity)
+	report.Observe("requests", podRequests.Total())
+	report.Observe("pods", podRequests)
+	// all the requests are sent from the same client
+	report.Observe("sticky", len(podRequests) == 1)
+
+	return nil
+}
@@ -0,0 +1,162 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tlspolicy
+
+import (
+	"strings"
+
+	"github.com/cucumber/godog"
+	"github.com/cucumber/messages-go/v10"
+
+	"sigs.k8s.io/ingress-controller-conformance/test/http"
+	"sigs.k8s.io/ingress-controller-conformance/test/kubernetes"
+	"sigs.k8s.io/ingress-controller-conformance/test/report"
+	tstate "sigs.k8s.io/ingress-controller-conformance/test/state"
+)
+
+var (
+	state *tstate.Scenario
+)
+
+// IMPORTANT: Steps definitions are generated and should not be modified
+// by hand but rather through make codegen. DO NOT EDIT.
+
+// InitializeScenario configures the Feature to test
+func InitializeScenario(ctx *godog.ScenarioContext) {
+	ctx.Step(`^a new random namespace$`, aNewRandomNamespace)
+	ctx.Step(`^a self-signed TLS secret named "([^"]*)" for the "([^"]*)" hostname$`, aSelfsignedTLSSecretNamedForTheHostname)
+	ctx.Step(`^an Ingress resource$`, anIngressResource)
+	ctx.Step(`^The Ingress status shows the IP address or FQDN where it is exposed$`, theIngressStatusShowsTheIPAddressOrFQDNWhereItIsExposed)
+	ctx.Step(`^the TLS versions accepted for the "([^"]*)" server name are probed$`, theTLSVersionsAcceptedForTheServerNameAreProbed)
+	ctx.Step(`^the results of the TLS probe are recorded$`, theResultsOfTheTLSProbeAreRecorded)
+	ctx.Step(`^the TLS versions configured as accepted must be accepted$`, theTLSVersionsConfiguredAsAcceptedMustBeAccepted)
+	ctx.Step(`^the TLS versions configured as rejected must be rejected$`, theTLSVersionsConfiguredAsRejectedMustBeRejected)
+	ctx.Step(`^the cipher suites accepted for the "([^"]*)" server name are probed$`, theCipherSuitesAcceptedForTheServerNameAreProbed)
+	ctx.Step(`^the ALPN protocols "([^"]*)" accepted for the "([^"]*)" server name are probed$`, theALPNProtocolsAcceptedForTheServerNameAreProbed)
+
+	ctx.BeforeScenario(func(*godog.Scenario) {
+		state = tstate.New()
+	})
+
+	ctx.AfterScenario(func(*messages.Pickle, error) {
+		// delete namespace an all the content
+		_ = kubernetes.DeleteNamespace(kubernetes.KubeClient, state.Namespace)
+	})
+}
+
+func aNewRandomNamespace() error {
+	ns, err := kubernetes.NewNamespace(kubernetes.KubeClient)
+	if err != nil {
+		return err
+	}
+
+	state.Namespace = ns
+	return nil
+}
+
+func aSelfsignedTLSSecretNamedForTheHostname(secretName string, host string) error {
+	err := kubernetes.NewSelfSignedSecret(kubernetes.KubeClient, state.Namespace, secretName, []string{host})
+	if err != nil {
+		return err
+	}
+
+	state.SecretName = secretName
+
+	return nil
+}
+
+func anIngressResource(spec *messages.PickleStepArgument_PickleDocString) error {
+	ingress, err := kubernetes.IngressFromManifest(state.Namespace, spec.GetContent())
+	if err != nil {
+		return err
+	}
+
+	err = kubernetes.DeploymentsFromIngress(kubernetes.KubeClient, ingress)
+	if err != nil {
+		return err
+	}
+
+	err = kubernetes.NewIngress(kubernetes.KubeClient, state.Namespace, ingress)
+	if err != nil {
+		return err
+	}
+
+	state.IngressName = ingress.GetName()
+
+	return nil
+}
+
+func theIngressStatusShowsTheIPAddressOrFQDNWhereItIsExposed() error {
+	ingress, err := kubernetes.WaitForIngressAddress(kubernetes.KubeClient, state.Namespace, state.IngressName)
+	if err != nil {
+		return err
+	}
+
+	state.IPOrFQDN = ingress
+
+	return err
+}
+
+func theTLSVersionsAcceptedForTheServerNameAreProbed(serverName string) error {
+	return state.ProbeTLSVersions(serverName)
+}
+
+func theResultsOfTheTLSProbeAreRecorded() error {
+	for _, result := range state.TLSProbeResults {
+		report.Observe(result.Name, result)
+	}
+
+	return nil
+}
+
+func theTLSVersionsConfiguredAsAcceptedMustBeAccepted() error {
+	if http.AcceptedTLSVersions == "" {
+		return nil
+	}
+
+	for _, version := range strings.Split(http.AcceptedTLSVersions, ",") {
+		err := state.AssertTLSProbeAccepted(strings.TrimSpace(version))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func theTLSVersionsConfiguredAsRejectedMustBeRejected() error {
+	if http.RejectedTLSVersions == "" {
+		return nil
+	}
+
+	for _, version := range strings.Split(http.RejectedTLSVersions, ",") {
+		err := state.AssertTLSProbeRejected(strings.TrimSpace(version))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func theCipherSuitesAcceptedForTheServerNameAreProbed(serverName string) error {
+	return state.ProbeCipherSuites(serverName)
+}
+
+func theALPNProtocolsAcceptedForTheServerNameAreProbed(protocols string, serverName string) error {
+	return state.ProbeALPN(serverName, strings.Split(protocols, ","))
+}
@@ -0,0 +1,185 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package distribution
+
+import (
+	"fmt"
+	"math"
+)
+
+var (
+	// MaxRatio maximum ratio between the number of requests served by the
+	// pod serving the most requests and the pod serving the fewest
+	MaxRatio = 3.0
+	// Significance significance level of the chi-square test used to check
+	// the requests are distributed uniformly between the pods
+	Significance = 0.001
+)
+
+// Histogram contains the number of requests served by each pod
+type Histogram map[string]int
+
+// Add records a request served by pod
+func (h Histogram) Add(pod string) {
+	h[pod]++
+}
+
+// Total returns the number of requests
+func (h Histogram) Total() int {
+	total := 0
+	for _, count := range h {
+		total += count
+	}
+
+	return total
+}
+
+// Ratio returns the ratio between the number of requests served by the pod serving the
+// most requests and the pod serving the fewest. When less than pods received requests
+// the ratio is infinite.
+func (h Histogram) Ratio(pods int) float64 {
+	if len(h) == 0 {
+		return math.Inf(1)
+	}
+
+	min, max := math.MaxInt64, 0
+	for _, count := range h {
+		if count < min {
+			min = count
+		}
+
+		if count > max {
+			max = count
+		}
+	}
+
+	if len(h) < pods || min == 0 {
+		return math.Inf(1)
+	}
+
+	return float64(max) / float64(min)
+}
+
+// ChiSquare returns the chi-square statistic and the p-value of the test
+// of goodness of fit to a uniform distribution between pods
+func (h Histogram) ChiSquare(pods int) (float64, float64) {
+	expected := float64(h.Total()) / float64(pods)
+
+	statistic := 0.0
+	for _, count := range h {
+		statistic += math.Pow(float64(count)-expected, 2) / expected
+	}
+
+	// pods without requests
+	if missing := pods - len(h); missing > 0 {
+		statistic += float64(missing) * expected
+	}
+
+	return statistic, upperIncompleteGamma(float64(pods-1)/2, statistic/2)
+}
+
+// AssertRatio returns an error if the ratio between the pods serving the
+// most and the fewest requests is greater than maximum
+func (h Histogram) AssertRatio(pods int, maximum float64) error {
+	if ratio := h.Ratio(pods); ratio > maximum {
+		return fmt.Errorf("expected a ratio between the pods serving the most and the fewest requests of at most %.2f but it was %.2f (%v)",
+			maximum, ratio, h)
+	}
+
+	return nil
+}
+
+// AssertUniform returns an error if the chi-square test rejects the
+// hypothesis that the requests are distributed uniformly between pods
+func (h Histogram) AssertUniform(pods int, significance float64) error {
+	if pods < 2 {
+		return fmt.Errorf("expected at least two pods to check the distribution of requests but %v was defined", pods)
+	}
+
+	if h.Total() == 0 {
+		return fmt.Errorf("expected requests served by %v pods but none was recorded", pods)
+	}
+
+	statistic, pValue := h.ChiSquare(pods)
+	if pValue < significance {
+		return fmt.Errorf("expected requests distributed uniformly between %v pods but the chi-square test rejected it (statistic %.2f, p-value %.6f, significance %v): %v",
+			pods, statistic, pValue, significance, h)
+	}
+
+	return nil
+}
+
+// upperIncompleteGamma returns the regularized upper incomplete gamma function Q(a, x)
+// (the survival function of the chi-square distribution is Q(df/2, x/2))
+func upperIncompleteGamma(a, x float64) float64 {
+	const (
+		iterations = 1000
+		epsilon    = 1e-14
+		tiny       = 1e-300
+	)
+
+	if x <= 0 {
+		return 1
+	}
+
+	lgamma, _ := math.Lgamma(a)
+	factor := math.Exp(-x + a*math.Log(x) - lgamma)
+
+	// series representation of the lower incomplete gamma function
+	if x < a+1 {
+		sum := 1 / a
+		term := sum
+		for n := 1; n < iterations; n++ {
+			term *= x / (a + float64(n))
+			sum += term
+			if math.Abs(term) < math.Abs(sum)*epsilon {
+				break
+			}
+		}
+
+		return 1 - sum*factor
+	}
+
+	// continued fraction representation (modified Lentz's method)
+	b := x + 1 - a
+	c := 1 / tiny
+	d := 1 / b
+	result := d
+	for n := 1; n < iterations; n++ {
+		an := -float64(n) * (float64(n) - a)
+		b += 2
+
+		d = an*d + b
+		if math.Abs(d) < tiny {
+			d = tiny
+		}
+
+		c = b + an/c
+		if math.Abs(c) < tiny {
+			c = tiny
+		}
+
+		d = 1 / d
+		delta := d * c
+		result *= delta
+		if math.Abs(delta-1) < epsilon {
+			break
+		}
+	}
+
+	return result * factor
+}
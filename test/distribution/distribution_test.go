@@ -0,0 +1,106 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package distribution
+
+import (
+	"math"
+	"testing"
+)
+
+func TestUpperIncompleteGamma(t *testing.T) {
+	tests := []struct {
+		statistic float64
+		df        int
+		pValue    float64
+	}{
+		{0, 4, 1},
+		{6, 2, 0.049787},
+		{3.841459, 1, 0.05},
+		{6.634897, 1, 0.01},
+		{21.665994, 9, 0.01},
+		{27.877165, 9, 0.001},
+		{8.342833, 9, 0.5},
+		{124.342113, 100, 0.05},
+	}
+
+	for _, test := range tests {
+		pValue := upperIncompleteGamma(float64(test.df)/2, test.statistic/2)
+		if math.Abs(pValue-test.pValue) > 1e-5 {
+			t.Errorf("expected p-value %v for statistic %v with %v degrees of freedom but %v was returned",
+				test.pValue, test.statistic, test.df, pValue)
+		}
+	}
+}
+
+func TestChiSquare(t *testing.T) {
+	tests := []struct {
+		name      string
+		histogram Histogram
+		pods      int
+		statistic float64
+		pValue    float64
+	}{
+		{"uniform", Histogram{"a": 100, "b": 100, "c": 100}, 3, 0, 1},
+		{"skewed", Histogram{"a": 80, "b": 100, "c": 120}, 3, 8, 0.0183156389},
+		{"pod without requests", Histogram{"a": 100}, 2, 100, 1.5239706e-23},
+	}
+
+	for _, test := range tests {
+		statistic, pValue := test.histogram.ChiSquare(test.pods)
+		if math.Abs(statistic-test.statistic) > 1e-9 {
+			t.Errorf("%v: expected statistic %v but %v was returned", test.name, test.statistic, statistic)
+		}
+
+		if math.Abs(pValue-test.pValue) > 1e-6*test.pValue {
+			t.Errorf("%v: expected p-value %v but %v was returned", test.name, test.pValue, pValue)
+		}
+	}
+}
+
+func TestRatio(t *testing.T) {
+	tests := []struct {
+		name      string
+		histogram Histogram
+		pods      int
+		ratio     float64
+	}{
+		{"even", Histogram{"a": 10, "b": 10}, 2, 1},
+		{"uneven", Histogram{"a": 10, "b": 30}, 2, 3},
+		{"pod without requests", Histogram{"a": 10, "b": 30}, 3, math.Inf(1)},
+		{"no requests", Histogram{}, 2, math.Inf(1)},
+	}
+
+	for _, test := range tests {
+		if ratio := test.histogram.Ratio(test.pods); ratio != test.ratio {
+			t.Errorf("%v: expected ratio %v but %v was returned", test.name, test.ratio, ratio)
+		}
+	}
+}
+
+func TestAssertUniform(t *testing.T) {
+	if err := (Histogram{"a": 98, "b": 102, "c": 100}).AssertUniform(3, 0.001); err != nil {
+		t.Errorf("expected a uniform distribution: %v", err)
+	}
+
+	if err := (Histogram{"a": 10, "b": 290}).AssertUniform(2, 0.001); err == nil {
+		t.Errorf("expected the chi-square test to reject a skewed distribution")
+	}
+
+	if err := (Histogram{"a": 150, "b": 150}).AssertUniform(3, 0.001); err == nil {
+		t.Errorf("expected the chi-square test to reject a distribution with a pod without requests")
+	}
+}
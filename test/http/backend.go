@@ -0,0 +1,86 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package http
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// SlowBackendDelay time the backend service waits before responding to exceed
+// the timeout of the ingress controller for requests sent to the backend
+var SlowBackendDelay = 90 * time.Second
+
+// BackendResponse defines the response the echoserver returns to a request.
+// The echoserver reads these values from query parameters of the request.
+type BackendResponse struct {
+	// StatusCode status code of the response. Zero means 200.
+	StatusCode int
+	// Headers headers to add to the response
+	Headers [][2]string
+	// Delay time to wait before sending the response
+	Delay time.Duration
+	// CloseConnection closes the connection after sending half of the response body
+	CloseConnection bool
+	// Abort closes the connection without sending a response
+	Abort bool
+}
+
+// Query returns the query parameters that request this response from the echoserver
+func (b *BackendResponse) Query() string {
+	values := url.Values{}
+
+	if b.StatusCode != 0 {
+		values.Set("echo-status", strconv.Itoa(b.StatusCode))
+	}
+
+	for _, header := range b.Headers {
+		values.Add("echo-header", fmt.Sprintf("%v:%v", header[0], header[1]))
+	}
+
+	if b.Delay > 0 {
+		values.Set("echo-delay", b.Delay.String())
+	}
+
+	if b.CloseConnection {
+		values.Set("echo-close", "true")
+	}
+
+	if b.Abort {
+		values.Set("echo-abort", "true")
+	}
+
+	return values.Encode()
+}
+
+// backendDelay returns the time the echoserver waits before responding
+// to a request with the query parameters in rawQuery
+func backendDelay(rawQuery string) time.Duration {
+	values, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return 0
+	}
+
+	delay, err := time.ParseDuration(values.Get("echo-delay"))
+	if err != nil {
+		return 0
+	}
+
+	return delay
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package http
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+)
+
+const (
+	// ContentLengthTransfer sends the request body using a Content-Length header
+	ContentLengthTransfer = "content-length"
+	// ChunkedTransfer sends the request body using chunked transfer encoding
+	ChunkedTransfer = "chunked"
+	// ExpectContinueTransfer sends the request body using a Content-Length header,
+	// waiting for a 100 Continue response before sending the body
+	ExpectContinueTransfer = "100-continue"
+)
+
+// RequestBody defines the body to send in a request. The content of the
+// body is generated from its size, so the same size always produces the same content.
+type RequestBody struct {
+	Size     int64
+	Transfer string
+}
+
+// NewRequestBody returns a new RequestBody with the specified size and transfer method
+func NewRequestBody(size int64, transfer string) (*RequestBody, error) {
+	switch transfer {
+	case ContentLengthTransfer, ChunkedTransfer, ExpectContinueTransfer:
+	default:
+		return nil, fmt.Errorf("unsupported body transfer %v", transfer)
+	}
+
+	if size < 0 {
+		return nil, fmt.Errorf("invalid body size %v", size)
+	}
+
+	return &RequestBody{
+		Size:     size,
+		Transfer: transfer,
+	}, nil
+}
+
+// SHA256 returns the hex encoded SHA-256 digest of the body content
+func (b *RequestBody) SHA256() (string, error) {
+	hash := sha256.New()
+	if _, err := io.Copy(hash, b.reader()); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// reader returns a new reader of the body content
+func (b *RequestBody) reader() io.Reader {
+	return io.LimitReader(rand.New(rand.NewSource(b.Size)), b.Size)
+}
+
+// apply configures the body and the headers of the request using the transfer method
+func (b *RequestBody) apply(req *http.Request) {
+	req.Body = ioutil.NopCloser(b.reader())
+	req.GetBody = func() (io.ReadCloser, error) {
+		return ioutil.NopCloser(b.reader()), nil
+	}
+
+	switch b.Transfer {
+	case ChunkedTransfer:
+		req.ContentLength = -1
+		req.TransferEncoding = []string{"chunked"}
+	case ExpectContinueTransfer:
+		req.ContentLength = b.Size
+		req.Header.Set("Expect", "100-continue")
+	default:
+		req.ContentLength = b.Size
+	}
+
+	if b.Size == 0 && b.Transfer != ChunkedTransfer {
+		req.Body = http.NoBody
+		req.GetBody = nil
+	}
+}
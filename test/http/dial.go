@@ -0,0 +1,129 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package http
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+)
+
+var (
+	// HTTPAddressOverride address (host or host:port) where all the HTTP requests are sent,
+	// instead of the address reported in the Ingress status
+	HTTPAddressOverride = ""
+	// HTTPSAddressOverride address (host or host:port) where all the HTTPS requests are sent,
+	// instead of the address reported in the Ingress status
+	HTTPSAddressOverride = ""
+	// AddressMap maps addresses reported in the Ingress status to the hosts where
+	// the requests are sent, keeping the port of the scheme of the request
+	AddressMap = map[string]string{}
+	// DNSServer address (host:port) of the DNS server used to resolve hostnames.
+	// The system resolver is used if empty.
+	DNSServer = ""
+)
+
+// AddressOverrideEnabled returns true if all the requests of a scheme are sent to the same address,
+// independently of the address of the Ingress status used to send them
+func AddressOverrideEnabled() bool {
+	return HTTPAddressOverride != "" || HTTPSAddressOverride != ""
+}
+
+// ParseAddressMap parses a comma separated list of status=host entries.
+// Hosts must not include a port, IPv6 addresses may be enclosed in brackets.
+func ParseAddressMap(value string) (map[string]string, error) {
+	addressMap := map[string]string{}
+	if strings.TrimSpace(value) == "" {
+		return addressMap, nil
+	}
+
+	for _, entry := range strings.Split(value, ",") {
+		parts := strings.SplitN(entry, "=", 2)
+		if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" || strings.TrimSpace(parts[1]) == "" {
+			return nil, fmt.Errorf("invalid address mapping %v (expected status=host)", entry)
+		}
+
+		host := strings.TrimSpace(parts[1])
+		if _, _, err := net.SplitHostPort(host); err == nil {
+			return nil, fmt.Errorf("invalid address mapping %v (the host must not include a port)", entry)
+		}
+
+		status := strings.TrimSuffix(strings.TrimPrefix(strings.TrimSpace(parts[0]), "["), "]")
+		addressMap[status] = strings.TrimSuffix(strings.TrimPrefix(host, "["), "]")
+	}
+
+	return addressMap, nil
+}
+
+// targetAddress returns the address (host:port) where a connection to addr is sent, applying
+// the override of the scheme of the request or the AddressMap entry of the host of addr
+func targetAddress(scheme, addr string) string {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return addr
+	}
+
+	target := HTTPAddressOverride
+	if scheme == "https" {
+		target = HTTPSAddressOverride
+	}
+
+	if target == "" {
+		target = AddressMap[host]
+	}
+
+	if target == "" {
+		return addr
+	}
+
+	// keep the port of the scheme if the target does not include one
+	if _, _, err := net.SplitHostPort(target); err == nil {
+		return target
+	}
+
+	return net.JoinHostPort(strings.TrimSuffix(strings.TrimPrefix(target, "["), "]"), port)
+}
+
+// newDialer returns a dialer that uses DNSServer to resolve hostnames, if configured
+func newDialer() *net.Dialer {
+	dialer := &net.Dialer{Timeout: HTTPClientTimeout}
+
+	if DNSServer != "" {
+		dialer.Resolver = &net.Resolver{
+			PreferGo: true,
+			Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+				d := &net.Dialer{Timeout: HTTPClientTimeout}
+				return d.DialContext(ctx, network, DNSServer)
+			},
+		}
+	}
+
+	return dialer
+}
+
+// dialContext returns a function that connects to the target address of requests sent using the scheme
+func dialContext(scheme string) func(ctx context.Context, network, addr string) (net.Conn, error) {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		return newDialer().DialContext(ctx, network, targetAddress(scheme, addr))
+	}
+}
+
+// dial connects to the target address of addr for a request sent using the scheme
+func dial(scheme, network, addr string) (net.Conn, error) {
+	return dialContext(scheme)(context.Background(), network, addr)
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package http
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseAddressMap(t *testing.T) {
+	tests := []struct {
+		name       string
+		value      string
+		addressMap map[string]string
+		err        bool
+	}{
+		{"empty", " ", map[string]string{}, false},
+		{"hosts", "10.0.0.10=203.0.113.10, lb.internal = lb.example.com", map[string]string{"10.0.0.10": "203.0.113.10", "lb.internal": "lb.example.com"}, false},
+		{"bare IPv6", "fd00::10=2001:db8::10", map[string]string{"fd00::10": "2001:db8::10"}, false},
+		{"bracketed IPv6", "[fd00::10]=[2001:db8::10]", map[string]string{"fd00::10": "2001:db8::10"}, false},
+		{"host with port", "10.0.0.10=203.0.113.10:8080", nil, true},
+		{"IPv6 with port", "fd00::10=[2001:db8::10]:8443", nil, true},
+		{"missing host", "10.0.0.10=", nil, true},
+		{"missing status", "=203.0.113.10", nil, true},
+		{"missing separator", "10.0.0.10", nil, true},
+	}
+
+	for _, test := range tests {
+		addressMap, err := ParseAddressMap(test.value)
+		if test.err {
+			if err == nil {
+				t.Errorf("%v: expected an error parsing %q but %v was returned", test.name, test.value, addressMap)
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("%v: unexpected error parsing %q: %v", test.name, test.value, err)
+			continue
+		}
+
+		if !reflect.DeepEqual(addressMap, test.addressMap) {
+			t.Errorf("%v: expected %v parsing %q but %v was returned", test.name, test.addressMap, test.value, addressMap)
+		}
+	}
+}
+
+func TestTargetAddress(t *testing.T) {
+	defer func(httpOverride, httpsOverride string, addressMap map[string]string) {
+		HTTPAddressOverride, HTTPSAddressOverride, AddressMap = httpOverride, httpsOverride, addressMap
+	}(HTTPAddressOverride, HTTPSAddressOverride, AddressMap)
+
+	tests := []struct {
+		name          string
+		httpOverride  string
+		httpsOverride string
+		addressMap    map[string]string
+		scheme        string
+		addr          string
+		target        string
+	}{
+		{"no replacement", "", "", nil, "http", "10.0.0.10:80", "10.0.0.10:80"},
+		{"address without port", "203.0.113.10", "", nil, "http", "10.0.0.10", "10.0.0.10"},
+		{"HTTP override", "203.0.113.10:8080", "203.0.113.10:8443", nil, "http", "10.0.0.10:80", "203.0.113.10:8080"},
+		{"HTTPS override", "203.0.113.10:8080", "203.0.113.10:8443", nil, "https", "10.0.0.10:443", "203.0.113.10:8443"},
+		{"override without port", "203.0.113.10", "", nil, "http", "10.0.0.10:80", "203.0.113.10:80"},
+		{"override of the other scheme", "203.0.113.10:8080", "", nil, "https", "10.0.0.10:443", "10.0.0.10:443"},
+		{"IPv6 override", "", "[2001:db8::10]:8443", nil, "https", "[fd00::10]:443", "[2001:db8::10]:8443"},
+		{"bracketed IPv6 override without port", "[2001:db8::10]", "", nil, "http", "10.0.0.10:80", "[2001:db8::10]:80"},
+		{"bare IPv6 override", "2001:db8::10", "", nil, "http", "10.0.0.10:80", "[2001:db8::10]:80"},
+		{"mapped address", "", "", map[string]string{"10.0.0.10": "203.0.113.10"}, "https", "10.0.0.10:443", "203.0.113.10:443"},
+		{"mapped IPv6 address", "", "", map[string]string{"fd00::10": "2001:db8::10"}, "http", "[fd00::10]:80", "[2001:db8::10]:80"},
+		{"mapped hostname", "", "", map[string]string{"lb.internal": "lb.example.com"}, "http", "lb.internal:8080", "lb.example.com:8080"},
+		{"unmapped address", "", "", map[string]string{"10.0.0.10": "203.0.113.10"}, "http", "10.0.0.11:80", "10.0.0.11:80"},
+		{"override before map", "203.0.113.20", "", map[string]string{"10.0.0.10": "203.0.113.10"}, "http", "10.0.0.10:80", "203.0.113.20:80"},
+	}
+
+	for _, test := range tests {
+		HTTPAddressOverride, HTTPSAddressOverride, AddressMap = test.httpOverride, test.httpsOverride, test.addressMap
+
+		if target := targetAddress(test.scheme, test.addr); target != test.target {
+			t.Errorf("%v: expected target address %v for %v %v but %v was returned", test.name, test.target, test.scheme, test.addr, target)
+		}
+	}
+}
@@ -17,11 +17,14 @@ limitations under the License.
 package http
 
 import (
+	"bufio"
 	"crypto/tls"
 	"crypto/x509"
 	"encoding/json"
 	"fmt"
+	"io"
 	"io/ioutil"
+	"net"
 	"net/http"
 	"net/http/httputil"
 	"regexp"
@@ -39,16 +42,36 @@ var (
 // CapturedRequest contains the original HTTP request metadata as received
 // by the echoserver handling the test request.
 type CapturedRequest struct {
-	Path    string              `json:"path"`
-	Host    string              `json:"host"`
-	Method  string              `json:"method"`
-	Proto   string              `json:"proto"`
-	Headers map[string][]string `json:"headers"`
+	Path       string              `json:"path"`
+	RawQuery   string              `json:"rawQuery"`
+	RequestURI string              `json:"requestURI"`
+	Host       string              `json:"host"`
+	Method     string              `json:"method"`
+	Proto      string              `json:"proto"`
+	Headers    map[string][]string `json:"headers"`
+	RemoteAddr string              `json:"remoteAddr"`
+
+	BodyLength int64  `json:"bodyLength"`
+	BodySHA256 string `json:"bodySHA256"`
+
+	InFlightRequests int64 `json:"inFlightRequests"`
 
 	Namespace string `json:"namespace"`
 	Ingress   string `json:"ingress"`
 	Service   string `json:"service"`
 	Pod       string `json:"pod"`
+
+	// TLS connection received by the echoserver, nil when the request was sent using plain HTTP
+	TLS *CapturedTLS `json:"tls,omitempty"`
+}
+
+// CapturedTLS contains the TLS connection metadata received by the echoserver.
+type CapturedTLS struct {
+	Version            string   `json:"version"`
+	PeerCertificates   []string `json:"peerCertificates,omitempty"`
+	ServerName         string   `json:"serverName"`
+	NegotiatedProtocol string   `json:"negotiatedProtocol,omitempty"`
+	CipherSuite        string   `json:"cipherSuite"`
 }
 
 // CapturedResponse contains the HTTP response metadata from the echoserver.
@@ -59,61 +82,39 @@ type CapturedResponse struct {
 	Headers       map[string][]string
 	TLSHostname   string
 
+	// Certificate leaf certificate presented by the server
 	Certificate *x509.Certificate
+	// Certificates complete chain presented by the server, starting with the leaf certificate
+	Certificates []*x509.Certificate
 }
 
-// CaptureRoundTrip will perform an HTTP request and return the CapturedRequest and CapturedResponse tuple
-func CaptureRoundTrip(method, scheme, hostname, path, location string) (*CapturedRequest, *CapturedResponse, error) {
-	var capturedTLSHostname string
-	var certificate *x509.Certificate
-
-	tr := &http.Transport{
-		DisableCompression: true,
-		TLSClientConfig: &tls.Config{
-			// Skip all usual TLS verifications, since we are using self-signed certificates.
-			InsecureSkipVerify: true,
-			VerifyPeerCertificate: func(certificates [][]byte, _ [][]*x509.Certificate) error {
-				certs := make([]*x509.Certificate, len(certificates))
-				for i, asn1Data := range certificates {
-					cert, err := x509.ParseCertificate(asn1Data)
-					if err != nil {
-						return fmt.Errorf("tls: failed to parse certificate from server: " + err.Error())
-					}
-					certs[i] = cert
-				}
-
-				capturedTLSHostname = certs[0].DNSNames[0]
-				certificate = certs[0]
-				return nil
-			},
-		},
-	}
-
-	if scheme == "https" && hostname != "" {
-		tr.TLSClientConfig.ServerName = hostname
-	}
+// CaptureRoundTrip will perform an HTTP request and return the CapturedRequest and CapturedResponse tuple.
+// The rawQuery, if not empty, is sent without modifications.
+func CaptureRoundTrip(method, scheme, hostname, path, rawQuery, location string) (*CapturedRequest, *CapturedResponse, error) {
+	return CaptureRoundTripWithBody(method, scheme, hostname, path, rawQuery, location, nil)
+}
 
-	client := &http.Client{
-		Transport: tr,
-		Timeout:   HTTPClientTimeout,
-		CheckRedirect: func(req *http.Request, via []*http.Request) error {
-			return http.ErrUseLastResponse
-		},
-	}
+// CaptureRoundTripWithBody will perform an HTTP request sending the specified body
+// and return the CapturedRequest and CapturedResponse tuple.
+func CaptureRoundTripWithBody(method, scheme, hostname, path, rawQuery, location string, body *RequestBody) (*CapturedRequest, *CapturedResponse, error) {
+	var serverCertificates capturedCertificates
 
-	url := fmt.Sprintf("%s://%s/%s", scheme, location, strings.TrimPrefix(path, "/"))
+	client := newClient(scheme, hostname, &serverCertificates)
+	// wait for the response delayed by the echoserver in addition to the usual timeout
+	client.Timeout += backendDelay(rawQuery)
 
-	req, err := http.NewRequest(method, url, nil)
+	req, err := newRequest(method, scheme, hostname, path, rawQuery, location)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	if hostname != "" {
-		req.Host = hostname
+	if body != nil {
+		body.apply(req)
 	}
 
 	if EnableDebug {
-		dump, err := httputil.DumpRequestOut(req, true)
+		// avoid the dump of generated request bodies
+		dump, err := httputil.DumpRequestOut(req, body == nil)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -128,12 +129,10 @@ func CaptureRoundTrip(method, scheme, hostname, path, location string) (*Capture
 	defer resp.Body.Close()
 
 	if EnableDebug {
-		dump, err := httputil.DumpResponse(resp, true)
+		err := dumpResponse(resp)
 		if err != nil {
 			return nil, nil, err
 		}
-
-		fmt.Printf("Received response:\n%s\n\n", formatDump(dump, "< "))
 	}
 
 	// check if the result is a redirect and return a new request
@@ -145,15 +144,192 @@ func CaptureRoundTrip(method, scheme, hostname, path, location string) (*Capture
 			return nil, nil, err
 		}
 
-		return CaptureRoundTrip(method, redirectURL.Scheme, redirectURL.Hostname(), redirectURL.Path, location)
+		return CaptureRoundTripWithBody(method, redirectURL.Scheme, redirectURL.Hostname(), redirectURL.Path, redirectURL.RawQuery, location, body)
+	}
+
+	return captureResponse(resp, &serverCertificates)
+}
+
+// CaptureRawRoundTrip will perform an HTTP/1.1 request using the request-target exactly as
+// defined, without any normalization or encoding, and return the CapturedRequest and
+// CapturedResponse tuple. Redirects are not followed.
+func CaptureRawRoundTrip(method, scheme, hostname, requestTarget, location string) (*CapturedRequest, *CapturedResponse, error) {
+	var serverCertificates capturedCertificates
+
+	conn, err := dial(scheme, "tcp", dialAddress(scheme, location))
+	if err != nil {
+		return nil, nil, err
+	}
+	defer conn.Close()
+
+	err = conn.SetDeadline(time.Now().Add(HTTPClientTimeout))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	host := hostname
+	if host == "" {
+		host = urlHost(location)
+	}
+
+	if scheme == "https" {
+		config := newTLSConfig(&serverCertificates)
+		if hostname != "" {
+			config.ServerName = hostname
+		}
+
+		tlsConn := tls.Client(conn, config)
+		if err := tlsConn.Handshake(); err != nil {
+			return nil, nil, err
+		}
+
+		conn = tlsConn
+	}
+
+	rawRequest := fmt.Sprintf("%s %s HTTP/1.1\r\nHost: %s\r\nUser-Agent: Go-http-client/1.1\r\nConnection: close\r\n\r\n",
+		method, requestTarget, host)
+
+	if EnableDebug {
+		fmt.Printf("Sending request:\n%s\n\n", formatDump([]byte(rawRequest), "> "))
+	}
+
+	_, err = io.WriteString(conn, rawRequest)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resp, err := http.ReadResponse(bufio.NewReader(conn), &http.Request{Method: method})
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+
+	if EnableDebug {
+		err := dumpResponse(resp)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return captureResponse(resp, &serverCertificates)
+}
+
+// dialAddress returns the address of location, using the default port of the scheme if location does not include a port
+func dialAddress(scheme, location string) string {
+	if _, _, err := net.SplitHostPort(location); err == nil {
+		return location
+	}
+
+	port := "80"
+	if scheme == "https" {
+		port = "443"
+	}
+
+	// IPv6 literals may be enclosed in brackets
+	host := strings.TrimSuffix(strings.TrimPrefix(location, "["), "]")
+
+	return net.JoinHostPort(host, port)
+}
+
+// urlHost returns location in the format used in URLs and Host headers,
+// enclosing IPv6 literals in brackets
+func urlHost(location string) string {
+	if ip := net.ParseIP(location); ip != nil && ip.To4() == nil {
+		return "[" + location + "]"
 	}
 
+	return location
+}
+
+// newClient returns an HTTP client that does not follow redirects and
+// captures the certificates presented by the server
+func newClient(scheme, hostname string, serverCertificates *capturedCertificates) *http.Client {
+	tr := &http.Transport{
+		DialContext:        dialContext(scheme),
+		DisableCompression: true,
+		TLSClientConfig:    newTLSConfig(serverCertificates),
+		// time to wait for a 100 Continue response when the request contains an Expect: 100-continue header
+		ExpectContinueTimeout: 1 * time.Second,
+	}
+
+	if scheme == "https" && hostname != "" {
+		tr.TLSClientConfig.ServerName = hostname
+	}
+
+	return &http.Client{
+		Transport: tr,
+		Timeout:   HTTPClientTimeout,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// newRequest returns a new request sent to location using hostname as the Host header
+func newRequest(method, scheme, hostname, path, rawQuery, location string) (*http.Request, error) {
+	url := fmt.Sprintf("%s://%s/%s", scheme, urlHost(location), strings.TrimPrefix(path, "/"))
+	if rawQuery != "" {
+		url = fmt.Sprintf("%s?%s", url, rawQuery)
+	}
+
+	req, err := http.NewRequest(method, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if hostname != "" {
+		req.Host = hostname
+	}
+
+	return req, nil
+}
+
+// capturedCertificates contains information about the certificates presented by the server
+type capturedCertificates struct {
+	hostname     string
+	certificate  *x509.Certificate
+	certificates []*x509.Certificate
+}
+
+// newTLSConfig returns a TLS client configuration that captures the certificates presented by the server
+func newTLSConfig(captured *capturedCertificates) *tls.Config {
+	return &tls.Config{
+		// Skip all usual TLS verifications, since we are using self-signed certificates.
+		// The captured chain can be verified later against a generated certificate authority.
+		InsecureSkipVerify: true,
+		VerifyPeerCertificate: func(certificates [][]byte, _ [][]*x509.Certificate) error {
+			certs := make([]*x509.Certificate, len(certificates))
+			for i, asn1Data := range certificates {
+				cert, err := x509.ParseCertificate(asn1Data)
+				if err != nil {
+					return fmt.Errorf("tls: failed to parse certificate from server: " + err.Error())
+				}
+				certs[i] = cert
+			}
+
+			if len(certs) == 0 {
+				return fmt.Errorf("tls: server did not present a certificate")
+			}
+
+			if len(certs[0].DNSNames) > 0 {
+				captured.hostname = certs[0].DNSNames[0]
+			}
+
+			captured.certificate = certs[0]
+			captured.certificates = certs
+			return nil
+		},
+	}
+}
+
+// captureResponse reads the response returned by the echoserver
+func captureResponse(resp *http.Response, serverCertificates *capturedCertificates) (*CapturedRequest, *CapturedResponse, error) {
 	capReq := CapturedRequest{}
 	body, _ := ioutil.ReadAll(resp.Body)
 
 	// we cannot assume the response is JSON
 	if isJSON(body) {
-		err = json.Unmarshal(body, &capReq)
+		err := json.Unmarshal(body, &capReq)
 		if err != nil {
 			return nil, nil, fmt.Errorf("unexpected error reading response: %w", err)
 		}
@@ -164,13 +340,24 @@ func CaptureRoundTrip(method, scheme, hostname, path, location string) (*Capture
 		resp.ContentLength,
 		resp.Proto,
 		resp.Header,
-		capturedTLSHostname,
-		certificate,
+		serverCertificates.hostname,
+		serverCertificates.certificate,
+		serverCertificates.certificates,
 	}
 
 	return &capReq, capRes, nil
 }
 
+func dumpResponse(resp *http.Response) error {
+	dump, err := httputil.DumpResponse(resp, true)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Received response:\n%s\n\n", formatDump(dump, "< "))
+	return nil
+}
+
 func isJSON(content []byte) bool {
 	var js map[string]interface{}
 	return json.Unmarshal(content, &js) == nil
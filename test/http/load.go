@@ -0,0 +1,165 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package http
+
+import (
+	"net/http"
+	"sync"
+	"time"
+)
+
+// MaxLoadErrorRate maximum percentage of failed requests tolerated while generating load
+var MaxLoadErrorRate = 1.0
+
+// loadRequestInterval time each client waits between requests
+const loadRequestInterval = 10 * time.Millisecond
+
+// LoadResults contains the outcome of the requests sent by a LoadGenerator
+type LoadResults struct {
+	// Requests total number of requests sent
+	Requests int
+	// Failures number of requests that returned an error or a status code other than 2xx
+	Failures int
+	// StatusCodes number of responses by status code
+	StatusCodes map[int]int
+	// Errors number of requests by error message
+	Errors map[string]int
+	// Pods number of responses by the pod that served the request
+	Pods map[string]int
+	// Duration time elapsed generating load
+	Duration time.Duration
+}
+
+// ErrorRate returns the percentage of failed requests
+func (r *LoadResults) ErrorRate() float64 {
+	if r.Requests == 0 {
+		return 0
+	}
+
+	return float64(r.Failures) * 100 / float64(r.Requests)
+}
+
+// LoadGenerator sends requests in the background until it is stopped
+type LoadGenerator struct {
+	method   string
+	scheme   string
+	hostname string
+	path     string
+
+	mu      sync.Mutex
+	results *LoadResults
+
+	start time.Time
+	stop  chan struct{}
+	wg    sync.WaitGroup
+}
+
+// StartLoadGenerator starts sending requests using the specified number of concurrent clients,
+// distributed between the locations. Each client reuses its connections and sends a new request
+// shortly after the previous one finishes. Redirects are not followed.
+func StartLoadGenerator(method, scheme, hostname, path string, locations []string, concurrency int) *LoadGenerator {
+	g := &LoadGenerator{
+		method:   method,
+		scheme:   scheme,
+		hostname: hostname,
+		path:     path,
+		results: &LoadResults{
+			StatusCodes: map[int]int{},
+			Errors:      map[string]int{},
+			Pods:        map[string]int{},
+		},
+		start: time.Now(),
+		stop:  make(chan struct{}),
+	}
+
+	for i := 0; i < concurrency; i++ {
+		g.wg.Add(1)
+		go g.run(locations[i%len(locations)])
+	}
+
+	return g
+}
+
+// Stop waits for the requests in progress and returns the results
+func (g *LoadGenerator) Stop() *LoadResults {
+	close(g.stop)
+	g.wg.Wait()
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.results.Duration = time.Since(g.start)
+	return g.results
+}
+
+func (g *LoadGenerator) run(location string) {
+	defer g.wg.Done()
+
+	var serverCertificates capturedCertificates
+
+	client := newClient(g.scheme, g.hostname, &serverCertificates)
+	defer client.CloseIdleConnections()
+
+	for {
+		capturedRequest, capturedResponse, err := g.roundTrip(client, location, &serverCertificates)
+		g.record(capturedRequest, capturedResponse, err)
+
+		select {
+		case <-g.stop:
+			return
+		case <-time.After(loadRequestInterval):
+		}
+	}
+}
+
+// roundTrip sends a request to location using client and captures the response
+func (g *LoadGenerator) roundTrip(client *http.Client, location string, serverCertificates *capturedCertificates) (*CapturedRequest, *CapturedResponse, error) {
+	req, err := newRequest(g.method, g.scheme, g.hostname, g.path, "", location)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+
+	return captureResponse(resp, serverCertificates)
+}
+
+func (g *LoadGenerator) record(capturedRequest *CapturedRequest, capturedResponse *CapturedResponse, err error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.results.Requests++
+
+	if err != nil {
+		g.results.Failures++
+		g.results.Errors[err.Error()]++
+		return
+	}
+
+	g.results.StatusCodes[capturedResponse.StatusCode]++
+	if capturedResponse.StatusCode < 200 || capturedResponse.StatusCode > 299 {
+		g.results.Failures++
+	}
+
+	if capturedRequest.Pod != "" {
+		g.results.Pods[capturedRequest.Pod]++
+	}
+}
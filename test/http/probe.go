@@ -0,0 +1,164 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package http
+
+import (
+	"crypto/tls"
+	"fmt"
+	"time"
+)
+
+// AcceptedTLSVersions comma separated list of TLS versions (TLS1.0, TLS1.1, TLS1.2 or TLS1.3)
+// the ingress controller must accept. Empty if there is no requirement.
+var AcceptedTLSVersions = ""
+
+// RejectedTLSVersions comma separated list of TLS versions (TLS1.0, TLS1.1, TLS1.2 or TLS1.3)
+// the ingress controller must reject. Empty if there is no requirement.
+var RejectedTLSVersions = ""
+
+// TLSProbeResult contains the outcome of a TLS handshake
+type TLSProbeResult struct {
+	// Name identifies the version, cipher suite or ALPN protocol offered by the client
+	Name     string `json:"name"`
+	Accepted bool   `json:"accepted"`
+	Error    string `json:"error,omitempty"`
+
+	Version            string `json:"version,omitempty"`
+	CipherSuite        string `json:"cipherSuite,omitempty"`
+	NegotiatedProtocol string `json:"negotiatedProtocol,omitempty"`
+}
+
+// TLSVersions TLS versions probed by ProbeTLSVersions
+var TLSVersions = map[string]uint16{
+	"TLS1.0": tls.VersionTLS10,
+	"TLS1.1": tls.VersionTLS11,
+	"TLS1.2": tls.VersionTLS12,
+	"TLS1.3": tls.VersionTLS13,
+}
+
+// ProbeTLSVersions attempts a TLS handshake with hostname as server name offering only one TLS version each time
+func ProbeTLSVersions(hostname, location string) []TLSProbeResult {
+	var results []TLSProbeResult
+	for _, name := range []string{"TLS1.0", "TLS1.1", "TLS1.2", "TLS1.3"} {
+		version := TLSVersions[name]
+		results = append(results, probeTLS(name, hostname, location, &tls.Config{
+			MinVersion: version,
+			MaxVersion: version,
+		}))
+	}
+
+	return results
+}
+
+// ProbeCipherSuites attempts a TLS 1.2 handshake with hostname as server name offering only one
+// cipher suite each time. TLS 1.3 cipher suites are not configurable and are not probed.
+func ProbeCipherSuites(hostname, location string) []TLSProbeResult {
+	suites := append(tls.CipherSuites(), tls.InsecureCipherSuites()...)
+
+	var results []TLSProbeResult
+	for _, suite := range suites {
+		if !supportsTLS12(suite) {
+			continue
+		}
+
+		results = append(results, probeTLS(suite.Name, hostname, location, &tls.Config{
+			MinVersion:   tls.VersionTLS10,
+			MaxVersion:   tls.VersionTLS12,
+			CipherSuites: []uint16{suite.ID},
+		}))
+	}
+
+	return results
+}
+
+// ProbeALPN attempts a TLS handshake with hostname as server name offering only one ALPN protocol
+// each time. The protocol is accepted if the server selects it.
+func ProbeALPN(hostname, location string, protocols []string) []TLSProbeResult {
+	var results []TLSProbeResult
+	for _, protocol := range protocols {
+		result := probeTLS(protocol, hostname, location, &tls.Config{
+			NextProtos: []string{protocol},
+		})
+
+		if result.Accepted && result.NegotiatedProtocol != protocol {
+			result.Accepted = false
+		}
+
+		results = append(results, result)
+	}
+
+	return results
+}
+
+// probeTLS attempts a TLS handshake using config
+func probeTLS(name, hostname, location string, config *tls.Config) TLSProbeResult {
+	result := TLSProbeResult{
+		Name: name,
+	}
+
+	// skip verifications, the probe only checks the handshake parameters
+	config.InsecureSkipVerify = true
+	config.ServerName = hostname
+
+	conn, err := dial("https", "tcp", dialAddress("https", location))
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	defer conn.Close()
+
+	err = conn.SetDeadline(time.Now().Add(HTTPClientTimeout))
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	tlsConn := tls.Client(conn, config)
+	if err := tlsConn.Handshake(); err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	connectionState := tlsConn.ConnectionState()
+
+	result.Accepted = true
+	result.Version = tlsVersionName(connectionState.Version)
+	result.CipherSuite = tls.CipherSuiteName(connectionState.CipherSuite)
+	result.NegotiatedProtocol = connectionState.NegotiatedProtocol
+
+	return result
+}
+
+func supportsTLS12(suite *tls.CipherSuite) bool {
+	for _, version := range suite.SupportedVersions {
+		if version == tls.VersionTLS12 {
+			return true
+		}
+	}
+
+	return false
+}
+
+func tlsVersionName(version uint16) string {
+	for name, value := range TLSVersions {
+		if value == version {
+			return name
+		}
+	}
+
+	return fmt.Sprintf("0x%04x", version)
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package http
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"time"
+)
+
+// StreamTimingTolerance time the pieces of a streamed response may arrive closer together
+// than they were sent by the backend service before the response is considered buffered
+var StreamTimingTolerance = 500 * time.Millisecond
+
+// StreamChunk contains information about a piece of a streamed response body
+type StreamChunk struct {
+	// Size number of bytes received
+	Size int
+	// Received elapsed time since the request was sent
+	Received time.Duration
+}
+
+// CapturedStream contains the metadata of a streamed HTTP response
+type CapturedStream struct {
+	StatusCode int
+	Proto      string
+	Headers    map[string][]string
+
+	// Chunks contains the pieces of the body in the order they were received
+	Chunks []StreamChunk
+	// Events number of server-sent events contained in the body
+	Events int
+}
+
+// CaptureStream will perform an HTTP request and read the response body as it is
+// received, recording the arrival time of each piece of the body.
+func CaptureStream(method, scheme, hostname, path, rawQuery, location string) (*CapturedStream, error) {
+	var serverCertificates capturedCertificates
+
+	client := newClient(scheme, hostname, &serverCertificates)
+
+	req, err := newRequest(method, scheme, hostname, path, rawQuery, location)
+	if err != nil {
+		return nil, err
+	}
+
+	if EnableDebug {
+		fmt.Printf("Sending stream request to %v (host %v)\n\n", req.URL, hostname)
+	}
+
+	start := time.Now()
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	stream := &CapturedStream{
+		StatusCode: resp.StatusCode,
+		Proto:      resp.Proto,
+		Headers:    resp.Header,
+	}
+
+	var body bytes.Buffer
+	buf := make([]byte, 32*1024)
+	for {
+		n, err := resp.Body.Read(buf)
+		if n > 0 {
+			stream.Chunks = append(stream.Chunks, StreamChunk{
+				Size:     n,
+				Received: time.Since(start),
+			})
+
+			body.Write(buf[:n])
+		}
+
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("reading stream: %w", err)
+		}
+	}
+
+	if EnableDebug {
+		fmt.Printf("Received stream:\n%s\n\n", formatDump(body.Bytes(), "< "))
+	}
+
+	// server-sent events are separated by blank lines
+	for _, event := range bytes.Split(body.Bytes(), []byte("\n\n")) {
+		if bytes.Contains(event, []byte("data:")) {
+			stream.Events++
+		}
+	}
+
+	return stream, nil
+}
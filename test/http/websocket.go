@@ -0,0 +1,206 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package http
+
+import (
+	"bufio"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// websocketGUID is concatenated to the Sec-WebSocket-Key header to compute the Sec-WebSocket-Accept header (RFC 6455)
+const websocketGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+// websocketTextFrame opcode of WebSocket text frames
+const websocketTextFrame = 0x1
+
+// maxWebSocketFrameLength maximum payload length of the WebSocket frames read from the server
+const maxWebSocketFrameLength = 1 << 20
+
+// CaptureWebSocket sends a WebSocket opening handshake and returns the CapturedRequest and CapturedResponse tuple.
+// When the handshake succeeds (status code 101), the CapturedRequest is read from the first message sent by the
+// echoserver. Otherwise the response is captured like any other HTTP response.
+func CaptureWebSocket(scheme, hostname, path, location string) (*CapturedRequest, *CapturedResponse, error) {
+	var serverCertificates capturedCertificates
+
+	conn, err := dial(scheme, "tcp", dialAddress(scheme, location))
+	if err != nil {
+		return nil, nil, err
+	}
+	defer conn.Close()
+
+	err = conn.SetDeadline(time.Now().Add(HTTPClientTimeout))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	host := hostname
+	if host == "" {
+		host = urlHost(location)
+	}
+
+	if scheme == "https" {
+		config := newTLSConfig(&serverCertificates)
+		if hostname != "" {
+			config.ServerName = hostname
+		}
+
+		tlsConn := tls.Client(conn, config)
+		if err := tlsConn.Handshake(); err != nil {
+			return nil, nil, err
+		}
+
+		conn = tlsConn
+	}
+
+	nonce := make([]byte, 16)
+	_, err = rand.Read(nonce)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	key := base64.StdEncoding.EncodeToString(nonce)
+
+	rawRequest := fmt.Sprintf("GET /%s HTTP/1.1\r\nHost: %s\r\nUser-Agent: Go-http-client/1.1\r\n"+
+		"Connection: Upgrade\r\nUpgrade: websocket\r\nSec-WebSocket-Key: %s\r\nSec-WebSocket-Version: 13\r\n\r\n",
+		strings.TrimPrefix(path, "/"), host, key)
+
+	if EnableDebug {
+		fmt.Printf("Sending request:\n%s\n\n", formatDump([]byte(rawRequest), "> "))
+	}
+
+	_, err = io.WriteString(conn, rawRequest)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	reader := bufio.NewReader(conn)
+
+	resp, err := http.ReadResponse(reader, &http.Request{Method: http.MethodGet})
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+
+	if EnableDebug {
+		err := dumpResponse(resp)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if resp.StatusCode != http.StatusSwitchingProtocols {
+		return captureResponse(resp, &serverCertificates)
+	}
+
+	if accept := websocketAccept(key); resp.Header.Get("Sec-WebSocket-Accept") != accept {
+		return nil, nil, fmt.Errorf("expected Sec-WebSocket-Accept header %v but %v was returned", accept, resp.Header.Get("Sec-WebSocket-Accept"))
+	}
+
+	opcode, payload, err := readWebSocketFrame(reader)
+	if err != nil {
+		return nil, nil, fmt.Errorf("reading WebSocket message: %w", err)
+	}
+
+	if opcode != websocketTextFrame {
+		return nil, nil, fmt.Errorf("expected a WebSocket text message but opcode %v was returned", opcode)
+	}
+
+	capReq := CapturedRequest{}
+	err = json.Unmarshal(payload, &capReq)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unexpected error reading WebSocket message: %w", err)
+	}
+
+	capRes := &CapturedResponse{
+		resp.StatusCode,
+		int64(len(payload)),
+		resp.Proto,
+		resp.Header,
+		serverCertificates.hostname,
+		serverCertificates.certificate,
+		serverCertificates.certificates,
+	}
+
+	return &capReq, capRes, nil
+}
+
+// websocketAccept returns the expected Sec-WebSocket-Accept header for key
+func websocketAccept(key string) string {
+	hash := sha1.Sum([]byte(key + websocketGUID))
+	return base64.StdEncoding.EncodeToString(hash[:])
+}
+
+// readWebSocketFrame reads a complete WebSocket frame returning its opcode and payload
+func readWebSocketFrame(reader *bufio.Reader) (byte, []byte, error) {
+	header := make([]byte, 2)
+	_, err := io.ReadFull(reader, header)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	opcode := header[0] & 0x0f
+	masked := header[1]&0x80 != 0
+
+	length := uint64(header[1] & 0x7f)
+	switch length {
+	case 126:
+		var extended uint16
+		err = binary.Read(reader, binary.BigEndian, &extended)
+		length = uint64(extended)
+	case 127:
+		err = binary.Read(reader, binary.BigEndian, &length)
+	}
+	if err != nil {
+		return 0, nil, err
+	}
+
+	if length > maxWebSocketFrameLength {
+		return 0, nil, fmt.Errorf("WebSocket frame of %v bytes exceeds the maximum of %v bytes", length, maxWebSocketFrameLength)
+	}
+
+	var mask [4]byte
+	if masked {
+		_, err = io.ReadFull(reader, mask[:])
+		if err != nil {
+			return 0, nil, err
+		}
+	}
+
+	payload := make([]byte, length)
+	_, err = io.ReadFull(reader, payload)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	if masked {
+		for i := range payload {
+			payload[i] ^= mask[i%4]
+		}
+	}
+
+	return opcode, payload, nil
+}
@@ -27,6 +27,8 @@ import (
 	networking "k8s.io/api/networking/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/kubernetes"
 	"sigs.k8s.io/yaml"
@@ -37,11 +39,40 @@ import (
 // EchoService name of the deployment for the echo app
 const EchoService = "echo"
 
-// EchoContainer container image name
-const EchoContainer = "k8s.gcr.io/ingressconformance/echoserver:v0.0.1@sha256:9b34b17f391f87fb2155f01da2f2f90b7a4a5c1110ed84cb5379faa4f570dc52"
+// DefaultEchoContainer released container image of the echo app
+const DefaultEchoContainer = "k8s.gcr.io/ingressconformance/echoserver:v0.0.1@sha256:9b34b17f391f87fb2155f01da2f2f90b7a4a5c1110ed84cb5379faa4f570dc52"
+
+// EchoContainer container image name. Features tagged @unreleased-echoserver require
+// an image built from images/echoserver and are skipped with DefaultEchoContainer.
+var EchoContainer = DefaultEchoContainer
+
+// BackendOptions customizes the deployments and services created for the backends of an Ingress
+type BackendOptions struct {
+	// SessionAffinity sets the spec.sessionAffinity field of the services (None if empty)
+	SessionAffinity corev1.ServiceAffinity
+	// TLS configures the echoserver to serve HTTPS using a generated self signed certificate.
+	// The service port targets the HTTPS port of the echoserver and sets appProtocol https.
+	TLS bool
+	// AppProtocol sets the appProtocol field of the service ports (http, https, kubernetes.io/h2c or kubernetes.io/ws).
+	// It overrides the appProtocol set by TLS.
+	AppProtocol string
+}
+
+// EchoDrainPeriod time the echoserver keeps serving requests after receiving the SIGTERM signal
+var EchoDrainPeriod = 10 * time.Second
+
+// echoShutdownPeriod time the echoserver may need to finish in-flight requests after the drain period
+const echoShutdownPeriod = 20 * time.Second
+
+const (
+	// echoHTTPSPort port where the echoserver serves HTTPS
+	echoHTTPSPort = 8443
+	// echoTLSPath directory where the TLS secret of the echoserver is mounted
+	echoTLSPath = "/etc/echoserver/tls"
+)
 
 // NewEchoDeployment creates a new deployment of the echoserver image in a particular namespace.
-func NewEchoDeployment(kubeClientSet kubernetes.Interface, namespace, name, serviceName, servicePortName string, servicePort int32) error {
+func NewEchoDeployment(kubeClientSet kubernetes.Interface, namespace, name, serviceName, servicePortName string, servicePort int32, options BackendOptions) error {
 	deploymentName := fmt.Sprintf("%v-%v", name, serviceName)
 
 	deployment, err := kubeClientSet.AppsV1().Deployments(namespace).Get(context.TODO(), deploymentName, metav1.GetOptions{})
@@ -60,13 +91,15 @@ func NewEchoDeployment(kubeClientSet kubernetes.Interface, namespace, name, serv
 	}
 
 	deploymentData := struct {
-		Name        string
-		MatchLabels string
-		Labels      string
-		Image       string
-		Ingress     string
-		Service     string
-		PortName    string
+		Name                          string
+		MatchLabels                   string
+		Labels                        string
+		Image                         string
+		Ingress                       string
+		Service                       string
+		PortName                      string
+		DrainPeriod                   string
+		TerminationGracePeriodSeconds int64
 	}{
 		deploymentName,
 		deploymentName,
@@ -75,6 +108,8 @@ func NewEchoDeployment(kubeClientSet kubernetes.Interface, namespace, name, serv
 		name,
 		serviceName,
 		servicePortName,
+		EchoDrainPeriod.String(),
+		int64((EchoDrainPeriod + echoShutdownPeriod).Seconds()),
 	}
 
 	manifest, err := templates.Render("deployment", deploymentData)
@@ -87,6 +122,13 @@ func NewEchoDeployment(kubeClientSet kubernetes.Interface, namespace, name, serv
 		return err
 	}
 
+	if options.TLS {
+		err = configureEchoTLS(kubeClientSet, namespace, serviceName, deployment)
+		if err != nil {
+			return err
+		}
+	}
+
 	err = displayYamlDefinition(deployment)
 	if err != nil {
 		return fmt.Errorf("unable show yaml definition: %v", err)
@@ -126,6 +168,21 @@ func NewEchoDeployment(kubeClientSet kubernetes.Interface, namespace, name, serv
 		service.Spec.Ports[0].Port = 8080
 	}
 
+	if options.SessionAffinity != "" {
+		service.Spec.SessionAffinity = options.SessionAffinity
+	}
+
+	if options.TLS {
+		appProtocol := "https"
+		service.Spec.Ports[0].TargetPort = intstr.FromInt(echoHTTPSPort)
+		service.Spec.Ports[0].AppProtocol = &appProtocol
+	}
+
+	if options.AppProtocol != "" {
+		appProtocol := options.AppProtocol
+		service.Spec.Ports[0].AppProtocol = &appProtocol
+	}
+
 	err = displayYamlDefinition(service)
 	if err != nil {
 		return fmt.Errorf("unable show yaml definition: %v", err)
@@ -144,13 +201,65 @@ func NewEchoDeployment(kubeClientSet kubernetes.Interface, namespace, name, serv
 	return nil
 }
 
+// configureEchoTLS creates a secret with a self signed certificate for the service and
+// configures the echoserver deployment to serve HTTPS using it
+func configureEchoTLS(kubeClientSet kubernetes.Interface, namespace, serviceName string, deployment *appsv1.Deployment) error {
+	secretName := fmt.Sprintf("%v-tls", deployment.Name)
+
+	hosts := []string{
+		serviceName,
+		fmt.Sprintf("%v.%v", serviceName, namespace),
+		fmt.Sprintf("%v.%v.svc", serviceName, namespace),
+		fmt.Sprintf("%v.%v.svc.cluster.local", serviceName, namespace),
+	}
+
+	err := NewSelfSignedSecret(kubeClientSet, namespace, secretName, hosts)
+	if err != nil {
+		return fmt.Errorf("creating TLS secret for deployment (%v): %w", deployment.Name, err)
+	}
+
+	podSpec := &deployment.Spec.Template.Spec
+	podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+		Name: "tls",
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName: secretName,
+			},
+		},
+	})
+
+	container := &podSpec.Containers[0]
+	container.Env = append(container.Env,
+		corev1.EnvVar{Name: "HTTPS_PORT", Value: fmt.Sprintf("%v", echoHTTPSPort)},
+		corev1.EnvVar{Name: "TLS_SERVER_CERT", Value: fmt.Sprintf("%v/%v", echoTLSPath, corev1.TLSCertKey)},
+		corev1.EnvVar{Name: "TLS_SERVER_PRIVKEY", Value: fmt.Sprintf("%v/%v", echoTLSPath, corev1.TLSPrivateKeyKey)},
+	)
+	container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+		Name:      "tls",
+		MountPath: echoTLSPath,
+		ReadOnly:  true,
+	})
+	container.Ports = append(container.Ports, corev1.ContainerPort{
+		Name:          "https",
+		ContainerPort: echoHTTPSPort,
+	})
+
+	return nil
+}
+
 // DeploymentsFromIngress creates the required deployments for the services defined in the ingress object
 func DeploymentsFromIngress(kubeClientSet kubernetes.Interface, ingress *networking.Ingress) error {
+	return DeploymentsFromIngressWithOptions(kubeClientSet, ingress, BackendOptions{})
+}
+
+// DeploymentsFromIngressWithOptions creates the required deployments for the services defined
+// in the ingress object, customized using options
+func DeploymentsFromIngressWithOptions(kubeClientSet kubernetes.Interface, ingress *networking.Ingress, options BackendOptions) error {
 	if ingress.Spec.DefaultBackend != nil {
 		service := ingress.Spec.DefaultBackend.Service
 		servicePort := service.Port
 
-		err := NewEchoDeployment(kubeClientSet, ingress.Namespace, ingress.Name, service.Name, servicePort.Name, servicePort.Number)
+		err := NewEchoDeployment(kubeClientSet, ingress.Namespace, ingress.Name, service.Name, servicePort.Name, servicePort.Number, options)
 		if err != nil {
 			return err
 		}
@@ -165,7 +274,7 @@ func DeploymentsFromIngress(kubeClientSet kubernetes.Interface, ingress *network
 			service := path.Backend.Service
 			servicePort := service.Port
 
-			err := NewEchoDeployment(kubeClientSet, ingress.Namespace, ingress.Name, service.Name, servicePort.Name, servicePort.Number)
+			err := NewEchoDeployment(kubeClientSet, ingress.Namespace, ingress.Name, service.Name, servicePort.Name, servicePort.Number, options)
 			if err != nil {
 				return err
 			}
@@ -204,6 +313,74 @@ func ScaleIngressBackendDeployment(kubeClientSet kubernetes.Interface, namespace
 	return nil
 }
 
+// WaitForServiceReadyEndpoints waits until the number of ready endpoints of a service is the expected one
+func WaitForServiceReadyEndpoints(kubeClientSet kubernetes.Interface, namespace, serviceName string, endpoints int) error {
+	err := waitForEndpoints(kubeClientSet, WaitForEndpointsTimeout, namespace, serviceName, endpoints)
+	if err != nil {
+		return fmt.Errorf("waiting for service (%v) with %v ready endpoints: %w", serviceName, endpoints, err)
+	}
+
+	// give time to the ingress controller to update the configuration
+	time.Sleep(60 * time.Second)
+
+	return nil
+}
+
+// RolloutIngressBackendDeployment performs a rolling update of a deployment defined in an ingress
+// service backend, replacing all the pods, and waits until the rollout is complete
+func RolloutIngressBackendDeployment(kubeClientSet kubernetes.Interface, namespace, name, serviceName string) error {
+	deploymentName := fmt.Sprintf("%v-%v", name, serviceName)
+
+	// same mechanism used by kubectl rollout restart
+	patch := fmt.Sprintf(`{"spec":{"template":{"metadata":{"annotations":{"kubectl.kubernetes.io/restartedAt":"%v"}}}}}`, time.Now().Format(time.RFC3339))
+
+	deployment, err := kubeClientSet.AppsV1().Deployments(namespace).Patch(context.TODO(), deploymentName, types.StrategicMergePatchType, []byte(patch), metav1.PatchOptions{})
+	if err != nil {
+		return fmt.Errorf("updating deployment (%v): %w", deploymentName, err)
+	}
+
+	err = wait.Poll(5*time.Second, WaitForEndpointsTimeout, func() (bool, error) {
+		d, err := kubeClientSet.AppsV1().Deployments(namespace).Get(context.TODO(), deploymentName, metav1.GetOptions{})
+		if err != nil {
+			return false, nil
+		}
+
+		return isRolloutComplete(d, deployment.Generation), nil
+	})
+	if err != nil {
+		return fmt.Errorf("waiting for rollout of deployment (%v): %w", deploymentName, err)
+	}
+
+	replicas := 1
+	if deployment.Spec.Replicas != nil {
+		replicas = int(*deployment.Spec.Replicas)
+	}
+
+	err = waitForEndpoints(kubeClientSet, WaitForEndpointsTimeout, namespace, serviceName, replicas)
+	if err != nil {
+		return fmt.Errorf("waiting for service (%v) endpoints available: %w", serviceName, err)
+	}
+
+	return nil
+}
+
+// isRolloutComplete checks all the pods of the deployment are updated and available
+// and there are no pods from previous revisions
+func isRolloutComplete(deployment *appsv1.Deployment, generation int64) bool {
+	if deployment.Status.ObservedGeneration < generation {
+		return false
+	}
+
+	replicas := int32(1)
+	if deployment.Spec.Replicas != nil {
+		replicas = *deployment.Spec.Replicas
+	}
+
+	return deployment.Status.UpdatedReplicas == replicas &&
+		deployment.Status.Replicas == replicas &&
+		deployment.Status.AvailableReplicas == replicas
+}
+
 // deploymentFromManifest deserializes a Deployment definition from a yaml string
 func deploymentFromManifest(manifest string) (*appsv1.Deployment, error) {
 	deployment := &appsv1.Deployment{}
@@ -224,26 +401,24 @@ func serviceFromManifest(manifest string) (*corev1.Service, error) {
 	return deployment, nil
 }
 
-// waitForEndpoints waits for a given amount of time until the number of endpoints = expectedEndpoints.
+// waitForEndpoints waits for a given amount of time until the number of ready endpoints = expectedEndpoints.
+// Zero expected endpoints waits until all the endpoints of the service are removed or not ready.
 func waitForEndpoints(kubeClientSet kubernetes.Interface, timeout time.Duration, ns, name string, expectedEndpoints int) error {
-	if expectedEndpoints == 0 {
-		return nil
-	}
-
 	return wait.Poll(5*time.Second, timeout, func() (bool, error) {
 		endpoint, err := kubeClientSet.CoreV1().Endpoints(ns).Get(context.TODO(), name, metav1.GetOptions{})
 		if apierrors.IsNotFound(err) {
-			return false, nil
+			return expectedEndpoints == 0, nil
 		}
 
-		if countReadyEndpoints(endpoint) == expectedEndpoints {
-			return true, nil
+		if err != nil {
+			return false, nil
 		}
 
-		return false, nil
+		return countReadyEndpoints(endpoint) == expectedEndpoints, nil
 	})
 }
 
+// countReadyEndpoints returns the number of ready addresses of the endpoints
 func countReadyEndpoints(e *corev1.Endpoints) int {
 	if e == nil || e.Subsets == nil {
 		return 0
@@ -17,21 +17,13 @@ limitations under the License.
 package kubernetes
 
 import (
-	"bytes"
 	"context"
-	"crypto/rand"
-	"crypto/rsa"
 	"crypto/x509"
-	"crypto/x509/pkix"
 	"encoding/pem"
 	"fmt"
-	"io"
-	"math/big"
-	"net"
 	"os"
 	"path/filepath"
 	"runtime"
-	"strings"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
@@ -47,6 +39,8 @@ import (
 	"k8s.io/client-go/tools/clientcmd"
 	"sigs.k8s.io/yaml"
 
+	"sigs.k8s.io/ingress-controller-conformance/test/certs"
+
 	// ensure auth plugins are loaded
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 )
@@ -204,45 +198,179 @@ func IngressFromManifest(namespace, manifest string) (*networking.Ingress, error
 	return ingress, nil
 }
 
+// TLSSecretVariant defines the content of a TLS secret created by NewTLSSecret
+type TLSSecretVariant string
+
+const (
+	// ValidTLSSecret secret of type kubernetes.io/tls with a valid self signed certificate
+	ValidTLSSecret TLSSecretVariant = "valid"
+	// MissingTLSSecret the secret is not created
+	MissingTLSSecret TLSSecretVariant = "missing"
+	// WrongTypeTLSSecret secret of type Opaque with a valid self signed certificate
+	WrongTypeTLSSecret TLSSecretVariant = "wrong-type"
+	// MalformedTLSSecret secret of type kubernetes.io/tls with content that is not a valid PEM certificate
+	MalformedTLSSecret TLSSecretVariant = "malformed"
+	// ExpiredTLSSecret secret of type kubernetes.io/tls with a self signed certificate expired a day ago
+	ExpiredTLSSecret TLSSecretVariant = "expired"
+)
+
 // NewSelfSignedSecret creates a self signed SSL certificate and store it in a secret
 func NewSelfSignedSecret(c clientset.Interface, namespace, secretName string, hosts []string) error {
+	return NewTLSSecret(c, namespace, secretName, hosts, ValidTLSSecret)
+}
+
+// NewTLSSecret creates a secret for the hosts with the content defined by variant
+func NewTLSSecret(c clientset.Interface, namespace, secretName string, hosts []string, variant TLSSecretVariant) error {
 	if len(hosts) == 0 {
 		return fmt.Errorf("require a non-empty hosts for Subject Alternate Name values")
 	}
 
-	var serverKey, serverCert bytes.Buffer
+	options := certs.Options{}
+	secretType := corev1.SecretTypeTLS
+
+	switch variant {
+	case ValidTLSSecret:
+	case MissingTLSSecret:
+		return nil
+	case WrongTypeTLSSecret:
+		secretType = corev1.SecretTypeOpaque
+	case MalformedTLSSecret:
+	case ExpiredTLSSecret:
+		options.NotBefore = time.Now().Add(-certs.DefaultValidity)
+		options.NotAfter = time.Now().Add(-24 * time.Hour)
+	default:
+		return fmt.Errorf("unknown TLS secret variant %v", variant)
+	}
+
+	certificate, err := certs.NewSelfSigned(hosts, options)
+	if err != nil {
+		return err
+	}
+
+	data, err := tlsSecretData(certificate)
+	if err != nil {
+		return err
+	}
+
+	if variant == MalformedTLSSecret {
+		// the PEM markers are valid but the content is not a certificate
+		data[corev1.TLSCertKey] = []byte("-----BEGIN CERTIFICATE-----\nbm90IGEgY2VydGlmaWNhdGU=\n-----END CERTIFICATE-----\n")
+	}
+
+	return newSecret(c, namespace, secretName, secretType, data)
+}
+
+// NewTLSSecretFromCertificate creates a TLS secret containing the certificate, the
+// certificates of the intermediate authorities that issued it and its private key
+func NewTLSSecretFromCertificate(c clientset.Interface, namespace, secretName string, certificate *certs.Certificate) error {
+	data, err := tlsSecretData(certificate)
+	if err != nil {
+		return err
+	}
+
+	return newSecret(c, namespace, secretName, corev1.SecretTypeTLS, data)
+}
+
+// RenewSelfSignedSecret regenerates in place the certificate stored in a TLS secret. The new
+// certificate is valid for the same hosts, with a new serial number and expiration date.
+func RenewSelfSignedSecret(c clientset.Interface, namespace, secretName string) error {
+	current, err := SecretCertificate(c, namespace, secretName)
+	if err != nil {
+		return err
+	}
+
+	hosts := current.DNSNames
+	for _, ip := range current.IPAddresses {
+		hosts = append(hosts, ip.String())
+	}
+
+	options := certs.Options{
+		NotBefore: time.Now(),
+	}
+
+	options.NotAfter = options.NotBefore.Add(certs.DefaultValidity)
+	if !options.NotAfter.After(current.NotAfter) {
+		options.NotAfter = current.NotAfter.Add(time.Hour)
+	}
+
+	certificate, err := certs.NewSelfSigned(hosts, options)
+	if err != nil {
+		return err
+	}
 
-	host := strings.Join(hosts, ",")
+	data, err := tlsSecretData(certificate)
+	if err != nil {
+		return err
+	}
 
-	if err := generateRSACert(host, &serverKey, &serverCert); err != nil {
+	secret, err := c.CoreV1().Secrets(namespace).Get(context.TODO(), secretName, metav1.GetOptions{})
+	if err != nil {
 		return err
 	}
 
-	data := map[string][]byte{
-		corev1.TLSCertKey:       serverCert.Bytes(),
-		corev1.TLSPrivateKeyKey: serverKey.Bytes(),
+	secret.Data = data
+
+	err = displayYamlDefinition(secret)
+	if err != nil {
+		return fmt.Errorf("unable show yaml definition: %v", err)
+	}
+
+	if _, err := c.CoreV1().Secrets(namespace).Update(context.TODO(), secret, metav1.UpdateOptions{}); err != nil {
+		return fmt.Errorf("updating secret (%v): %w", secretName, err)
+	}
+
+	return nil
+}
+
+// tlsSecretData returns the content of a TLS secret for the certificate
+func tlsSecretData(certificate *certs.Certificate) (map[string][]byte, error) {
+	key, err := certificate.PrivateKeyPEM()
+	if err != nil {
+		return nil, err
 	}
 
-	newSecret := &corev1.Secret{
+	return map[string][]byte{
+		corev1.TLSCertKey:       certificate.ChainPEM(),
+		corev1.TLSPrivateKeyKey: key,
+	}, nil
+}
+
+func newSecret(c clientset.Interface, namespace, secretName string, secretType corev1.SecretType, data map[string][]byte) error {
+	secret := &corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: secretName,
 		},
-		Type: corev1.SecretTypeTLS,
+		Type: secretType,
 		Data: data,
 	}
 
-	err := displayYamlDefinition(newSecret)
+	err := displayYamlDefinition(secret)
 	if err != nil {
 		return fmt.Errorf("unable show yaml definition: %v", err)
 	}
 
-	if _, err := c.CoreV1().Secrets(namespace).Create(context.TODO(), newSecret, metav1.CreateOptions{}); err != nil {
+	if _, err := c.CoreV1().Secrets(namespace).Create(context.TODO(), secret, metav1.CreateOptions{}); err != nil {
 		return err
 	}
 
 	return nil
 }
 
+// SecretCertificate returns the certificate stored in a TLS secret
+func SecretCertificate(c clientset.Interface, namespace, secretName string) (*x509.Certificate, error) {
+	secret, err := c.CoreV1().Secrets(namespace).Get(context.TODO(), secretName, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode(secret.Data[corev1.TLSCertKey])
+	if block == nil {
+		return nil, fmt.Errorf("secret %v does not contain a PEM encoded certificate", secretName)
+	}
+
+	return x509.ParseCertificate(block.Bytes)
+}
+
 const (
 	// ingressWaitInterval time to wait between checks for a condition
 	ingressWaitInterval = 5 * time.Second
@@ -253,6 +381,8 @@ var (
 	WaitForIngressAddressTimeout = 5 * time.Minute
 	// WaitForEndpointsTimeout maximum wait time for ready endpoints
 	WaitForEndpointsTimeout = 5 * time.Minute
+	// WaitForCertificateRotationTimeout maximum wait time for the ingress controller to present an updated certificate
+	WaitForCertificateRotationTimeout = 2 * time.Minute
 
 	// EnableOutputYamlDefinitions display yaml definitions of Kubernetes objects before creation
 	EnableOutputYamlDefinitions = false
@@ -271,12 +401,13 @@ func WaitForIngressAddress(c clientset.Interface, namespace, name string) (strin
 			return false, err
 		}
 
-		address = ipOrNameList[0]
-		return true, nil
+		// the status may not contain an address of the selected family yet
+		address = SelectIngressAddress(ipOrNameList, IngressAddressFamily)
+		return address != "", nil
 	})
 
 	if err != nil {
-		return "", fmt.Errorf("waiting for ingress status update: %w", err)
+		return "", fmt.Errorf("waiting for ingress status update (%v address): %w", IngressAddressFamily, err)
 	}
 
 	return address, nil
@@ -320,67 +451,6 @@ func isRetryableAPIError(err error) bool {
 	return false
 }
 
-const (
-	rsaBits  = 2048
-	validFor = 365 * 24 * time.Hour
-)
-
-// generateRSACert generates a basic self signed certificate valir for a year
-func generateRSACert(host string, keyOut, certOut io.Writer) error {
-	priv, err := rsa.GenerateKey(rand.Reader, rsaBits)
-	if err != nil {
-		return fmt.Errorf("failed to generate key: %v", err)
-	}
-	notBefore := time.Now()
-	notAfter := notBefore.Add(validFor)
-
-	serialNumberLimit := new(big.Int).Lsh(big.NewInt(1), 128)
-	serialNumber, err := rand.Int(rand.Reader, serialNumberLimit)
-
-	if err != nil {
-		return fmt.Errorf("failed to generate serial number: %s", err)
-	}
-
-	template := x509.Certificate{
-		SerialNumber: serialNumber,
-		Subject: pkix.Name{
-			CommonName:   "default",
-			Organization: []string{"Acme Co"},
-		},
-		NotBefore: notBefore,
-		NotAfter:  notAfter,
-
-		KeyUsage:              x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature,
-		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
-		BasicConstraintsValid: true,
-	}
-
-	hosts := strings.Split(host, ",")
-	for _, h := range hosts {
-		if ip := net.ParseIP(h); ip != nil {
-			template.IPAddresses = append(template.IPAddresses, ip)
-		} else {
-			template.DNSNames = append(template.DNSNames, h)
-		}
-	}
-
-	derBytes, err := x509.CreateCertificate(rand.Reader, &template, &template, &priv.PublicKey, priv)
-
-	if err != nil {
-		return fmt.Errorf("failed to create certificate: %s", err)
-	}
-
-	if err := pem.Encode(certOut, &pem.Block{Type: "CERTIFICATE", Bytes: derBytes}); err != nil {
-		return fmt.Errorf("failed creating cert: %v", err)
-	}
-
-	if err := pem.Encode(keyOut, &pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(priv)}); err != nil {
-		return fmt.Errorf("failed creating key: %v", err)
-	}
-
-	return nil
-}
-
 func displayYamlDefinition(obj apiruntime.Object) error {
 	if !EnableOutputYamlDefinitions {
 		return nil
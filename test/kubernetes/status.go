@@ -0,0 +1,310 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+	"time"
+
+	networking "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/wait"
+	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/retry"
+)
+
+// IngressStatusStabilityPeriod time the status of an Ingress must not change after an update
+var IngressStatusStabilityPeriod = 30 * time.Second
+
+// IngressAddress is an entry of the status.loadBalancer.ingress field of an Ingress.
+// The ports field is not available in the Ingress API types of Kubernetes 1.19,
+// so the status is decoded from the raw Ingress object.
+type IngressAddress struct {
+	IP       string              `json:"ip,omitempty"`
+	Hostname string              `json:"hostname,omitempty"`
+	Ports    []IngressPortStatus `json:"ports,omitempty"`
+}
+
+// IngressPortStatus is an entry of the ports field of an IngressAddress
+type IngressPortStatus struct {
+	Port     int32   `json:"port"`
+	Protocol string  `json:"protocol"`
+	Error    *string `json:"error,omitempty"`
+}
+
+// Address returns the IP address or the hostname of the entry
+func (a IngressAddress) Address() string {
+	if a.IP != "" {
+		return a.IP
+	}
+
+	return a.Hostname
+}
+
+// Validate returns an error if the entry does not contain a valid IP address or DNS name
+func (a IngressAddress) Validate() error {
+	if a.IP == "" && a.Hostname == "" {
+		return fmt.Errorf("status entry without IP address or hostname")
+	}
+
+	if a.IP != "" && net.ParseIP(a.IP) == nil {
+		return fmt.Errorf("invalid IP address %v", a.IP)
+	}
+
+	if a.Hostname != "" {
+		if net.ParseIP(a.Hostname) != nil {
+			return fmt.Errorf("hostname %v must be a DNS name, not an IP address", a.Hostname)
+		}
+
+		if errs := validation.IsDNS1123Subdomain(a.Hostname); len(errs) > 0 {
+			return fmt.Errorf("invalid hostname %v: %v", a.Hostname, strings.Join(errs, ", "))
+		}
+	}
+
+	return nil
+}
+
+// ValidatePorts returns an error if any entry of the ports field is invalid
+func (a IngressAddress) ValidatePorts() error {
+	for _, port := range a.Ports {
+		if errs := validation.IsValidPortNum(int(port.Port)); len(errs) > 0 {
+			return fmt.Errorf("invalid port %v of %v: %v", port.Port, a.Address(), strings.Join(errs, ", "))
+		}
+
+		switch port.Protocol {
+		case "TCP", "UDP", "SCTP":
+		default:
+			return fmt.Errorf("invalid protocol %v for port %v of %v", port.Protocol, port.Port, a.Address())
+		}
+	}
+
+	return nil
+}
+
+// IngressStatusAddresses returns the entries of the status.loadBalancer.ingress field of the Ingress
+func IngressStatusAddresses(c clientset.Interface, namespace, name string) ([]IngressAddress, error) {
+	raw, err := c.NetworkingV1().RESTClient().Get().
+		Namespace(namespace).
+		Resource("ingresses").
+		Name(name).
+		DoRaw(context.TODO())
+	if err != nil {
+		return nil, err
+	}
+
+	var ingress struct {
+		Status struct {
+			LoadBalancer struct {
+				Ingress []IngressAddress `json:"ingress"`
+			} `json:"loadBalancer"`
+		} `json:"status"`
+	}
+
+	err = json.Unmarshal(raw, &ingress)
+	if err != nil {
+		return nil, fmt.Errorf("decoding Ingress status: %w", err)
+	}
+
+	return ingress.Status.LoadBalancer.Ingress, nil
+}
+
+// WaitForIngressStatusAddresses waits for the Ingress to acquire an address and returns all the entries of the status
+func WaitForIngressStatusAddresses(c clientset.Interface, namespace, name string) ([]IngressAddress, error) {
+	var addresses []IngressAddress
+	err := wait.PollImmediate(ingressWaitInterval, WaitForIngressAddressTimeout, func() (bool, error) {
+		var err error
+		addresses, err = IngressStatusAddresses(c, namespace, name)
+		if err != nil {
+			if isRetryableAPIError(err) {
+				return false, nil
+			}
+
+			return false, err
+		}
+
+		return len(addresses) > 0, nil
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("waiting for ingress status update: %w", err)
+	}
+
+	return addresses, nil
+}
+
+// WaitForSameIngressStatusAddresses waits for the status of the Ingress to contain the same addresses
+func WaitForSameIngressStatusAddresses(c clientset.Interface, namespace, name string, addresses []IngressAddress) error {
+	var current []IngressAddress
+	err := wait.PollImmediate(ingressWaitInterval, WaitForIngressAddressTimeout, func() (bool, error) {
+		var err error
+		current, err = IngressStatusAddresses(c, namespace, name)
+		if err != nil {
+			if isRetryableAPIError(err) {
+				return false, nil
+			}
+
+			return false, err
+		}
+
+		return SameIngressAddresses(addresses, current), nil
+	})
+
+	if err == wait.ErrWaitTimeout {
+		return fmt.Errorf("expected the Ingress %v to report the addresses %v but %v were returned",
+			name, ingressAddressList(addresses), ingressAddressList(current))
+	}
+
+	return err
+}
+
+// WaitForIngressStatusStability checks the addresses in the status of the Ingress
+// do not change during IngressStatusStabilityPeriod
+func WaitForIngressStatusStability(c clientset.Interface, namespace, name string, addresses []IngressAddress) error {
+	err := wait.PollImmediate(ingressWaitInterval, IngressStatusStabilityPeriod, func() (bool, error) {
+		current, err := IngressStatusAddresses(c, namespace, name)
+		if err != nil {
+			if isRetryableAPIError(err) {
+				return false, nil
+			}
+
+			return false, err
+		}
+
+		if !SameIngressAddresses(addresses, current) {
+			return false, fmt.Errorf("expected Ingress status addresses %v but %v were returned",
+				ingressAddressList(addresses), ingressAddressList(current))
+		}
+
+		return false, nil
+	})
+
+	if err == wait.ErrWaitTimeout {
+		return nil
+	}
+
+	return err
+}
+
+// SameIngressAddresses returns true if both lists contain the same IP addresses and hostnames, in any order
+func SameIngressAddresses(a, b []IngressAddress) bool {
+	first, second := ingressAddressList(a), ingressAddressList(b)
+	if len(first) != len(second) {
+		return false
+	}
+
+	for i := range first {
+		if first[i] != second[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ingressAddressList returns the sorted IP addresses and hostnames of the entries
+func ingressAddressList(addresses []IngressAddress) []string {
+	var list []string
+	for _, address := range addresses {
+		list = append(list, address.Address())
+	}
+
+	sort.Strings(list)
+	return list
+}
+
+// AddIngressPath updates the first rule of the Ingress adding a path with the
+// specified value and the same backend as the first path of the rule
+func AddIngressPath(c clientset.Interface, namespace, name, path string) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		ingress, err := c.NetworkingV1().Ingresses(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+
+		if len(ingress.Spec.Rules) == 0 || ingress.Spec.Rules[0].HTTP == nil || len(ingress.Spec.Rules[0].HTTP.Paths) == 0 {
+			return fmt.Errorf("the Ingress %v does not contain a rule with paths", name)
+		}
+
+		pathType := networking.PathTypePrefix
+
+		rule := ingress.Spec.Rules[0].HTTP
+		rule.Paths = append(rule.Paths, networking.HTTPIngressPath{
+			Path:     path,
+			PathType: &pathType,
+			Backend:  rule.Paths[0].Backend,
+		})
+
+		_, err = c.NetworkingV1().Ingresses(namespace).Update(context.TODO(), ingress, metav1.UpdateOptions{})
+		return err
+	})
+}
+
+// Address families of the addresses in the Ingress status
+const (
+	// AnyAddressFamily selects the first address in the Ingress status
+	AnyAddressFamily = "any"
+	// IPv4AddressFamily selects the first IPv4 address
+	IPv4AddressFamily = "ipv4"
+	// IPv6AddressFamily selects the first IPv6 address
+	IPv6AddressFamily = "ipv6"
+	// HostnameAddressFamily selects the first hostname
+	HostnameAddressFamily = "hostname"
+	// DualStackAddressFamily selects the first IPv4 address when the status also contains an IPv6 address.
+	// Requests are sent using the first address of both families.
+	DualStackAddressFamily = "dual"
+)
+
+// IngressAddressFamily family of the address in the Ingress status used to send requests
+var IngressAddressFamily = AnyAddressFamily
+
+// AddressFamily returns the family of an IP address or hostname (ipv4, ipv6 or hostname)
+func AddressFamily(address string) string {
+	ip := net.ParseIP(address)
+	switch {
+	case ip == nil:
+		return HostnameAddressFamily
+	case ip.To4() != nil:
+		return IPv4AddressFamily
+	default:
+		return IPv6AddressFamily
+	}
+}
+
+// SelectIngressAddress returns the first address of the family, or an empty string if there is none
+func SelectIngressAddress(addresses []string, family string) string {
+	if family == DualStackAddressFamily {
+		if SelectIngressAddress(addresses, IPv6AddressFamily) == "" {
+			return ""
+		}
+
+		family = IPv4AddressFamily
+	}
+
+	for _, address := range addresses {
+		if family == AnyAddressFamily || AddressFamily(address) == family {
+			return address
+		}
+	}
+
+	return ""
+}
@@ -40,6 +40,7 @@ spec:
       labels:
         app: {{ .Labels }}
     spec:
+      terminationGracePeriodSeconds: {{ .TerminationGracePeriodSeconds }}
       containers:
       - name: ingress-conformance-echo
         image: {{ .Image }}
@@ -56,12 +57,14 @@ spec:
           value: {{ .Ingress }}
         - name: SERVICE_NAME
           value: {{ .Service }}
+        - name: DRAIN_PERIOD
+          value: "{{ .DrainPeriod }}"
         ports:
         - name: {{ .PortName }}
           containerPort: 3000
         livenessProbe:
           httpGet:
-            path: /health
+            path: /live
             port: 3000
             scheme: HTTP
           initialDelaySeconds: 1
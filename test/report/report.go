@@ -0,0 +1,143 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/cucumber/godog"
+)
+
+// Observation contains information about the behavior of an ingress controller
+// that is recorded in the report instead of being asserted by a step.
+type Observation struct {
+	Name  string      `json:"name"`
+	Value interface{} `json:"value"`
+}
+
+type stepKey struct {
+	scenario string
+	step     string
+}
+
+var (
+	currentScenario string
+	currentStep     string
+
+	observations = map[stepKey][]Observation{}
+	// order of the steps containing observations
+	observedSteps []stepKey
+)
+
+// Register configures hooks to keep track of the scenario and step
+// being executed, required to associate observations with steps.
+func Register(ctx *godog.ScenarioContext) {
+	ctx.BeforeScenario(func(sc *godog.Scenario) {
+		currentScenario = sc.Name
+		currentStep = ""
+	})
+
+	ctx.BeforeStep(func(st *godog.Step) {
+		currentStep = st.Text
+	})
+}
+
+// Observe records a named value for the step being executed
+func Observe(name string, value interface{}) {
+	key := stepKey{currentScenario, currentStep}
+	if _, ok := observations[key]; !ok {
+		observedSteps = append(observedSteps, key)
+	}
+
+	observations[key] = append(observations[key], Observation{name, value})
+}
+
+// Reset removes all the recorded observations
+func Reset() {
+	observations = map[stepKey][]Observation{}
+	observedSteps = nil
+}
+
+// Embed adds the recorded observations to a cucumber JSON report as
+// application/json embeddings of the steps where they were observed.
+func Embed(cucumberReport []byte) ([]byte, error) {
+	if len(observations) == 0 {
+		return cucumberReport, nil
+	}
+
+	var features []map[string]interface{}
+	if err := json.Unmarshal(cucumberReport, &features); err != nil {
+		return nil, fmt.Errorf("unexpected error reading cucumber report: %w", err)
+	}
+
+	for _, feature := range features {
+		elements, _ := feature["elements"].([]interface{})
+		for _, e := range elements {
+			element, ok := e.(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			scenario, _ := element["name"].(string)
+			steps, _ := element["steps"].([]interface{})
+			for _, s := range steps {
+				step, ok := s.(map[string]interface{})
+				if !ok {
+					continue
+				}
+
+				name, _ := step["name"].(string)
+				stepObservations, ok := observations[stepKey{scenario, name}]
+				if !ok {
+					continue
+				}
+
+				data, err := json.Marshal(stepObservations)
+				if err != nil {
+					return nil, err
+				}
+
+				embeddings, _ := step["embeddings"].([]interface{})
+				step["embeddings"] = append(embeddings, map[string]interface{}{
+					"mime_type": "application/json",
+					"data":      string(data),
+				})
+			}
+		}
+	}
+
+	return json.MarshalIndent(features, "", "    ")
+}
+
+// Print writes the recorded observations in a human readable format
+func Print(w io.Writer) error {
+	for _, key := range observedSteps {
+		data, err := json.MarshalIndent(observations[key], "  ", " ")
+		if err != nil {
+			return err
+		}
+
+		_, err = fmt.Fprintf(w, "Observations\n  Scenario: %v\n  Step: %v\n  %s\n\n", key.scenario, key.step, data)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -17,10 +17,15 @@ limitations under the License.
 package state
 
 import (
+	"crypto/x509"
 	"fmt"
+	"sort"
 	"strings"
+	"time"
 
 	"sigs.k8s.io/ingress-controller-conformance/test/http"
+	"sigs.k8s.io/ingress-controller-conformance/test/kubernetes"
+	"sigs.k8s.io/ingress-controller-conformance/test/report"
 )
 
 // Scenario holds state for a test scenario
@@ -33,7 +38,31 @@ type Scenario struct {
 	CapturedRequest  *http.CapturedRequest
 	CapturedResponse *http.CapturedResponse
 
+	// CapturedRequests requests received by the backend service for the last request sent to
+	// each address (IPOrFQDN and, using the dual address family, the IPv6 address)
+	CapturedRequests []*http.CapturedRequest
+
+	// RequestError error returned by the last request that may fail
+	RequestError error
+
+	RequestBody *http.RequestBody
+
+	// BackendResponse defines the response returned by the backend service in the next requests
+	BackendResponse *http.BackendResponse
+
+	CapturedStream *http.CapturedStream
+
+	// LoadGenerator sends requests in the background while other steps run
+	LoadGenerator *http.LoadGenerator
+	LoadResults   *http.LoadResults
+
+	// TLSProbeResults outcome of the TLS handshakes attempted by the last probe
+	TLSProbeResults []http.TLSProbeResult
+
 	IPOrFQDN string
+
+	// ipv6Address IPv6 address of the Ingress status used to send requests with the dual address family
+	ipv6Address string
 }
 
 // New creates a new state to use in a test Scenario
@@ -41,19 +70,346 @@ func New() *Scenario {
 	return &Scenario{}
 }
 
-// CaptureRoundTrip will perform an HTTP request and return the CapturedRequest and CapturedResponse tuple
-func (s *Scenario) CaptureRoundTrip(method, scheme, hostname, path string) error {
-	capturedRequest, capturedResponse, err := http.CaptureRoundTrip(method, scheme, hostname, path, s.IPOrFQDN)
+// CaptureRoundTrip will perform an HTTP request and return the CapturedRequest and CapturedResponse tuple.
+// Using the dual address family, the request is sent to an address of each IP family (see sendToEachAddress).
+func (s *Scenario) CaptureRoundTrip(method, scheme, hostname, path, rawQuery string) error {
+	rawQuery = s.withBackendResponse(rawQuery)
+
+	return s.captureRoundTrips(func(location string) (*http.CapturedRequest, *http.CapturedResponse, error) {
+		return http.CaptureRoundTrip(method, scheme, hostname, path, rawQuery, location)
+	})
+}
+
+// CaptureRoundTripThatMayFail will perform an HTTP request like CaptureRoundTrip, storing the error
+// in RequestError instead of returning it. The outcome of these requests is recorded, and they may
+// make the backend service crash or wait for the timeout of the ingress controller, so they are
+// only sent to IPOrFQDN, even using the dual address family.
+func (s *Scenario) CaptureRoundTripThatMayFail(method, scheme, hostname, path, rawQuery string) {
+	capturedRequest, capturedResponse, err := http.CaptureRoundTrip(method, scheme, hostname, path, s.withBackendResponse(rawQuery), s.IPOrFQDN)
+	s.RequestError = s.storeRoundTrip(capturedRequest, capturedResponse, err)
+}
+
+// CaptureControlRoundTrip will perform an HTTP request that changes the state of the pod of the backend
+// service that receives it, like the readiness reported by the echoserver. The request is only sent to
+// IPOrFQDN, even using the dual address family, so a single pod receives it.
+func (s *Scenario) CaptureControlRoundTrip(method, scheme, hostname, path, rawQuery string) error {
+	capturedRequest, capturedResponse, err := http.CaptureRoundTrip(method, scheme, hostname, path, rawQuery, s.IPOrFQDN)
+	return s.storeRoundTrip(capturedRequest, capturedResponse, err)
+}
+
+// ObserveResponse records the error returned by the last request that may fail or, when the
+// request succeeded, the status code and the service that returned the response. The service
+// is empty when the response was not returned by the echoserver.
+// It returns false if the request failed.
+func (s *Scenario) ObserveResponse() bool {
+	if s.RequestError != nil {
+		report.Observe("error", s.RequestError.Error())
+		return false
+	}
+
+	report.Observe("statusCode", s.CapturedResponse.StatusCode)
+	report.Observe("service", s.CapturedRequest.Service)
+
+	return true
+}
+
+// CaptureRoundTripWithBody will perform an HTTP request sending the specified body
+// and return the CapturedRequest and CapturedResponse tuple
+func (s *Scenario) CaptureRoundTripWithBody(method, scheme, hostname, path, rawQuery string, body *http.RequestBody) error {
+	rawQuery = s.withBackendResponse(rawQuery)
+
+	err := s.captureRoundTrips(func(location string) (*http.CapturedRequest, *http.CapturedResponse, error) {
+		return http.CaptureRoundTripWithBody(method, scheme, hostname, path, rawQuery, location, body)
+	})
+	if err != nil {
+		return err
+	}
+
+	s.RequestBody = body
+
+	return nil
+}
+
+// CaptureRoundTripWithBodyThatMayFail will perform an HTTP request like CaptureRoundTripWithBody,
+// storing the error in RequestError instead of returning it. Like CaptureRoundTripThatMayFail,
+// the request is only sent to IPOrFQDN.
+func (s *Scenario) CaptureRoundTripWithBodyThatMayFail(method, scheme, hostname, path, rawQuery string, body *http.RequestBody) {
+	capturedRequest, capturedResponse, err := http.CaptureRoundTripWithBody(method, scheme, hostname, path, s.withBackendResponse(rawQuery), s.IPOrFQDN, body)
+	s.RequestError = s.storeRoundTrip(capturedRequest, capturedResponse, err)
+	s.RequestBody = body
+}
+
+// CaptureStream will perform an HTTP request and record the arrival time of each piece of the response body
+func (s *Scenario) CaptureStream(method, scheme, hostname, path, rawQuery string) error {
+	var capturedStreams []*http.CapturedStream
+
+	err := s.sendToEachAddress(func(location string) (string, error) {
+		capturedStream, err := http.CaptureStream(method, scheme, hostname, path, rawQuery, location)
+		if err != nil {
+			return "", err
+		}
+
+		capturedStreams = append(capturedStreams, capturedStream)
+		return fmt.Sprintf("status code %v with %v events", capturedStream.StatusCode, capturedStream.Events), nil
+	})
+	if err != nil {
+		return err
+	}
+
+	s.CapturedStream = capturedStreams[0]
+
+	return nil
+}
+
+// StartLoad starts sending requests in the background using the specified number of concurrent clients.
+// Using the dual address family, the clients are distributed between an address of each IP family.
+func (s *Scenario) StartLoad(method, scheme, hostname, path string, concurrency int) error {
+	if s.LoadGenerator != nil {
+		return fmt.Errorf("requests are already being sent in the background")
+	}
+
+	locations, err := s.locations()
+	if err != nil {
+		return err
+	}
+
+	s.LoadGenerator = http.StartLoadGenerator(method, scheme, hostname, path, locations, concurrency)
+	return nil
+}
+
+// StopLoad stops sending requests in the background and captures the results
+func (s *Scenario) StopLoad() error {
+	if s.LoadGenerator == nil {
+		return fmt.Errorf("no requests are being sent in the background")
+	}
+
+	s.LoadResults = s.LoadGenerator.Stop()
+	s.LoadGenerator = nil
+
+	return nil
+}
+
+// ProbeTLSVersions attempts a TLS handshake for each TLS version
+func (s *Scenario) ProbeTLSVersions(hostname string) error {
+	return s.probeTLS(func(location string) []http.TLSProbeResult {
+		return http.ProbeTLSVersions(hostname, location)
+	})
+}
+
+// ProbeCipherSuites attempts a TLS handshake for each TLS 1.2 cipher suite
+func (s *Scenario) ProbeCipherSuites(hostname string) error {
+	return s.probeTLS(func(location string) []http.TLSProbeResult {
+		return http.ProbeCipherSuites(hostname, location)
+	})
+}
+
+// ProbeALPN attempts a TLS handshake for each ALPN protocol
+func (s *Scenario) ProbeALPN(hostname string, protocols []string) error {
+	return s.probeTLS(func(location string) []http.TLSProbeResult {
+		return http.ProbeALPN(hostname, location, protocols)
+	})
+}
+
+// probeTLS runs the TLS probe using each address where requests are sent and stores the results of
+// the first address. Every address must accept the same versions, cipher suites or ALPN protocols.
+func (s *Scenario) probeTLS(probe func(location string) []http.TLSProbeResult) error {
+	var probeResults [][]http.TLSProbeResult
+
+	err := s.sendToEachAddress(func(location string) (string, error) {
+		results := probe(location)
+		probeResults = append(probeResults, results)
+
+		var accepted []string
+		for _, result := range results {
+			if result.Accepted {
+				accepted = append(accepted, result.Name)
+			}
+		}
+
+		sort.Strings(accepted)
+		return fmt.Sprintf("accepted %v", accepted), nil
+	})
+	if err != nil {
+		return err
+	}
+
+	s.TLSProbeResults = probeResults[0]
+
+	return nil
+}
+
+// CaptureRawRoundTrip will perform an HTTP request using the request-target exactly as defined
+// and return the CapturedRequest and CapturedResponse tuple
+func (s *Scenario) CaptureRawRoundTrip(method, scheme, hostname, requestTarget string) error {
+	return s.captureRoundTrips(func(location string) (*http.CapturedRequest, *http.CapturedResponse, error) {
+		return http.CaptureRawRoundTrip(method, scheme, hostname, requestTarget, location)
+	})
+}
+
+// CaptureWebSocket will send a WebSocket opening handshake and return the CapturedRequest and CapturedResponse tuple
+func (s *Scenario) CaptureWebSocket(scheme, hostname, path string) error {
+	return s.captureRoundTrips(func(location string) (*http.CapturedRequest, *http.CapturedResponse, error) {
+		return http.CaptureWebSocket(scheme, hostname, path, location)
+	})
+}
+
+// captureRoundTrips sends a request to each address where requests are sent using capture and stores
+// the CapturedRequest and CapturedResponse tuple of the first address. The responses returned using
+// every address must have the same status code and be served by the same service.
+func (s *Scenario) captureRoundTrips(capture func(location string) (*http.CapturedRequest, *http.CapturedResponse, error)) error {
+	var capturedRequests []*http.CapturedRequest
+	var capturedResponses []*http.CapturedResponse
+
+	err := s.sendToEachAddress(func(location string) (string, error) {
+		capturedRequest, capturedResponse, err := capture(location)
+		if err != nil {
+			return "", err
+		}
+
+		capturedRequests = append(capturedRequests, capturedRequest)
+		capturedResponses = append(capturedResponses, capturedResponse)
+		return fmt.Sprintf("status code %v served by %q", capturedResponse.StatusCode, capturedRequest.Service), nil
+	})
+	if err != nil {
+		return err
+	}
+
+	s.CapturedRequest = capturedRequests[0]
+	s.CapturedResponse = capturedResponses[0]
+	s.CapturedRequests = capturedRequests
+
+	return nil
+}
+
+// storeRoundTrip stores the CapturedRequest and CapturedResponse tuple of a request sent to IPOrFQDN
+func (s *Scenario) storeRoundTrip(capturedRequest *http.CapturedRequest, capturedResponse *http.CapturedResponse, err error) error {
 	if err != nil {
 		return err
 	}
 
 	s.CapturedRequest = capturedRequest
 	s.CapturedResponse = capturedResponse
+	s.CapturedRequests = []*http.CapturedRequest{capturedRequest}
 
 	return nil
 }
 
+// sendToEachAddress calls send with each address where requests are sent. The result returned by
+// send summarizes the response and must be the same using every address. Using the dual address
+// family, the request is sent to IPOrFQDN (IPv4) and to the first IPv6 address of the Ingress status.
+func (s *Scenario) sendToEachAddress(send func(location string) (string, error)) error {
+	locations, err := s.locations()
+	if err != nil {
+		return err
+	}
+
+	var expected string
+	for i, location := range locations {
+		result, err := send(location)
+		if err != nil {
+			if len(locations) > 1 {
+				return fmt.Errorf("sending request to %v: %w", location, err)
+			}
+
+			return err
+		}
+
+		if i == 0 {
+			expected = result
+			continue
+		}
+
+		if result != expected {
+			return fmt.Errorf("expected the same response using the addresses %v but %v (%v) and %v (%v) were returned",
+				strings.Join(locations, ", "), expected, locations[0], result, location)
+		}
+	}
+
+	return nil
+}
+
+// locations returns the addresses where requests are sent: IPOrFQDN and, using the
+// dual address family, the first IPv6 address of the Ingress status
+func (s *Scenario) locations() ([]string, error) {
+	if kubernetes.IngressAddressFamily != kubernetes.DualStackAddressFamily {
+		return []string{s.IPOrFQDN}, nil
+	}
+
+	if s.ipv6Address == "" {
+		addresses, err := kubernetes.IngressStatusAddresses(kubernetes.KubeClient, s.Namespace, s.IngressName)
+		if err != nil {
+			return nil, err
+		}
+
+		var ipOrNameList []string
+		for _, address := range addresses {
+			ipOrNameList = append(ipOrNameList, address.Address())
+		}
+
+		s.ipv6Address = kubernetes.SelectIngressAddress(ipOrNameList, kubernetes.IPv6AddressFamily)
+		if s.ipv6Address == "" {
+			return nil, fmt.Errorf("the Ingress status does not contain an IPv6 address")
+		}
+	}
+
+	return []string{s.IPOrFQDN, s.ipv6Address}, nil
+}
+
+// SetBackendStatusCode configures the status code returned by the backend service in the next requests
+func (s *Scenario) SetBackendStatusCode(statusCode int) {
+	s.backendResponse().StatusCode = statusCode
+}
+
+// AddBackendResponseHeader configures a header returned by the backend service in the next requests
+func (s *Scenario) AddBackendResponseHeader(key, value string) {
+	backendResponse := s.backendResponse()
+	backendResponse.Headers = append(backendResponse.Headers, [2]string{key, value})
+}
+
+// SetBackendDelay configures the time the backend service waits before sending the response in the next requests
+func (s *Scenario) SetBackendDelay(delay time.Duration) {
+	s.backendResponse().Delay = delay
+}
+
+// SetBackendCloseConnection configures the backend service to close the connection
+// in the middle of the response in the next requests
+func (s *Scenario) SetBackendCloseConnection() {
+	s.backendResponse().CloseConnection = true
+}
+
+// SetBackendAbort configures the backend service to close the connection
+// without sending a response in the next requests
+func (s *Scenario) SetBackendAbort() {
+	s.backendResponse().Abort = true
+}
+
+// ResetBackendResponse configures the backend service to return the default response in the next requests
+func (s *Scenario) ResetBackendResponse() {
+	s.BackendResponse = nil
+}
+
+func (s *Scenario) backendResponse() *http.BackendResponse {
+	if s.BackendResponse == nil {
+		s.BackendResponse = &http.BackendResponse{}
+	}
+
+	return s.BackendResponse
+}
+
+// withBackendResponse adds to rawQuery the query parameters that configure the backend response
+func (s *Scenario) withBackendResponse(rawQuery string) string {
+	if s.BackendResponse == nil {
+		return rawQuery
+	}
+
+	query := s.BackendResponse.Query()
+	if rawQuery == "" {
+		return query
+	}
+
+	return fmt.Sprintf("%v&%v", rawQuery, query)
+}
+
 // AssertStatusCode returns an error if the captured response status code does not match the expected value
 func (s *Scenario) AssertStatusCode(statusCode int) error {
 	if s.CapturedResponse.StatusCode != statusCode {
@@ -108,6 +464,20 @@ func (s *Scenario) AssertRequestProto(proto string) error {
 	return nil
 }
 
+// AssertRequestTLS returns an error if the backend service did not receive the request using TLS
+// when encrypted is true, or received it using TLS when encrypted is false
+func (s *Scenario) AssertRequestTLS(encrypted bool) error {
+	if encrypted && s.CapturedRequest.TLS == nil {
+		return fmt.Errorf("expected the request to be received using TLS")
+	}
+
+	if !encrypted && s.CapturedRequest.TLS != nil {
+		return fmt.Errorf("expected the request to be received without TLS but it used %v", s.CapturedRequest.TLS.Version)
+	}
+
+	return nil
+}
+
 // AssertMethod returns an error if the captured request method does not match the expected value
 func (s *Scenario) AssertMethod(method string) error {
 	if s.CapturedRequest.Method != method {
@@ -130,6 +500,24 @@ func (s *Scenario) AssertRequestPath(path string) error {
 	return nil
 }
 
+// AssertRequestQuery returns an error if the captured request query string does not match the expected value
+func (s *Scenario) AssertRequestQuery(rawQuery string) error {
+	if s.CapturedRequest.RawQuery != rawQuery {
+		return fmt.Errorf("expected the request query string to be %v but it was %v", rawQuery, s.CapturedRequest.RawQuery)
+	}
+
+	return nil
+}
+
+// AssertRequestURI returns an error if the captured request-target does not match the expected value
+func (s *Scenario) AssertRequestURI(requestURI string) error {
+	if s.CapturedRequest.RequestURI != requestURI {
+		return fmt.Errorf("expected the request-target to be %v but it was %v", requestURI, s.CapturedRequest.RequestURI)
+	}
+
+	return nil
+}
+
 // AssertResponseHeader returns an error if the captured response headers do not contain the expected headerKey,
 // or if the matching response header value does not match the expected headerValue.
 // If the headerValue string equals `*`, the header value check is ignored.
@@ -168,6 +556,190 @@ func (s *Scenario) AssertRequestHeader(headerKey string, headerValue string) err
 	return nil
 }
 
+// AssertRequestBody returns an error if the captured request body length or SHA-256 digest
+// does not match the body sent in the request
+func (s *Scenario) AssertRequestBody() error {
+	if s.RequestBody == nil {
+		return fmt.Errorf("body verification requires executing a request with a body")
+	}
+
+	if s.CapturedRequest.BodyLength != s.RequestBody.Size {
+		return fmt.Errorf("expected the request body length to be %v but it was %v", s.RequestBody.Size, s.CapturedRequest.BodyLength)
+	}
+
+	digest, err := s.RequestBody.SHA256()
+	if err != nil {
+		return err
+	}
+
+	if s.CapturedRequest.BodySHA256 != digest {
+		return fmt.Errorf("expected the request body SHA-256 digest to be %v but it was %v", digest, s.CapturedRequest.BodySHA256)
+	}
+
+	return nil
+}
+
+// AssertStreamStatusCode returns an error if the captured stream status code does not match the expected value
+func (s *Scenario) AssertStreamStatusCode(statusCode int) error {
+	if s.CapturedStream.StatusCode != statusCode {
+		return fmt.Errorf("expected stream status code %v but %v was returned", statusCode, s.CapturedStream.StatusCode)
+	}
+
+	return nil
+}
+
+// AssertStreamEvents returns an error if the number of server-sent events in the captured stream
+// does not match the expected value
+func (s *Scenario) AssertStreamEvents(events int) error {
+	if s.CapturedStream.Events != events {
+		return fmt.Errorf("expected %v server-sent events but %v were received", events, s.CapturedStream.Events)
+	}
+
+	return nil
+}
+
+// AssertStreamNotBuffered returns an error if the first piece of the captured stream was not
+// received at least minimum time before the last one, meaning the response was buffered
+func (s *Scenario) AssertStreamNotBuffered(minimum time.Duration) error {
+	chunks := s.CapturedStream.Chunks
+	if len(chunks) < 2 {
+		return fmt.Errorf("expected a stream received in multiple pieces but %v were received", len(chunks))
+	}
+
+	first := chunks[0].Received
+	last := chunks[len(chunks)-1].Received
+	if last-first < minimum {
+		return fmt.Errorf("expected the first piece of the stream to be received at least %v before the last one but it was received at %v and the last one at %v",
+			minimum, first, last)
+	}
+
+	return nil
+}
+
+// AssertLoadErrorRate returns an error if the percentage of failed requests sent in the background
+// is greater than maximum or no requests were sent
+func (s *Scenario) AssertLoadErrorRate(maximum float64) error {
+	if s.LoadResults.Requests == 0 {
+		return fmt.Errorf("expected requests sent in the background but none was sent")
+	}
+
+	if errorRate := s.LoadResults.ErrorRate(); errorRate > maximum {
+		return fmt.Errorf("expected an error rate of at most %.2f%% but %v of %v requests failed (%.2f%%). Status codes: %v, errors: %v",
+			maximum, s.LoadResults.Failures, s.LoadResults.Requests, errorRate, s.LoadResults.StatusCodes, s.LoadResults.Errors)
+	}
+
+	return nil
+}
+
+// AssertTLSProbeAccepted returns an error if the handshake of the last TLS probe identified by name was rejected
+func (s *Scenario) AssertTLSProbeAccepted(name string) error {
+	result, err := s.tlsProbeResult(name)
+	if err != nil {
+		return err
+	}
+
+	if !result.Accepted {
+		return fmt.Errorf("expected the TLS handshake offering %v to be accepted but it failed: %v", name, result.Error)
+	}
+
+	return nil
+}
+
+// AssertTLSProbeRejected returns an error if the handshake of the last TLS probe identified by name was accepted
+func (s *Scenario) AssertTLSProbeRejected(name string) error {
+	result, err := s.tlsProbeResult(name)
+	if err != nil {
+		return err
+	}
+
+	if result.Accepted {
+		return fmt.Errorf("expected the TLS handshake offering %v to be rejected but it was accepted (version %v, cipher suite %v)",
+			name, result.Version, result.CipherSuite)
+	}
+
+	return nil
+}
+
+func (s *Scenario) tlsProbeResult(name string) (*http.TLSProbeResult, error) {
+	for i := range s.TLSProbeResults {
+		if s.TLSProbeResults[i].Name == name {
+			return &s.TLSProbeResults[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("there is no TLS probe result for %v", name)
+}
+
+// AssertForwardedProto returns an error if the captured request contains X-Forwarded-Proto or
+// Forwarded headers and the protocol of the original request does not match the expected value.
+// Requests without these headers are considered valid.
+func (s *Scenario) AssertForwardedProto(proto string) error {
+	for _, value := range s.CapturedRequest.Headers["X-Forwarded-Proto"] {
+		// the first entry contains the protocol used by the client
+		forwardedProto := strings.TrimSpace(strings.Split(value, ",")[0])
+		if !strings.EqualFold(forwardedProto, proto) {
+			return fmt.Errorf("expected the X-Forwarded-Proto request header to be %v but it was %v", proto, value)
+		}
+	}
+
+	for _, value := range s.CapturedRequest.Headers["Forwarded"] {
+		// the first element contains the parameters of the request sent by the client
+		element := strings.Split(value, ",")[0]
+		for _, pair := range strings.Split(element, ";") {
+			kv := strings.SplitN(strings.TrimSpace(pair), "=", 2)
+			if len(kv) != 2 || !strings.EqualFold(kv[0], "proto") {
+				continue
+			}
+
+			forwardedProto := strings.Trim(kv[1], `"`)
+			if !strings.EqualFold(forwardedProto, proto) {
+				return fmt.Errorf("expected the Forwarded request header proto to be %v but it was %v", proto, value)
+			}
+		}
+	}
+
+	return nil
+}
+
+// AssertServedCertificate returns an error if the certificate presented by the server is not the expected one
+func (s *Scenario) AssertServedCertificate(certificate *x509.Certificate) error {
+	if s.CapturedResponse == nil || s.CapturedResponse.Certificate == nil {
+		return fmt.Errorf("certificate verification requires executing a request and also target an HTTPS URL")
+	}
+
+	served := s.CapturedResponse.Certificate
+	if !served.Equal(certificate) {
+		return fmt.Errorf("expected the certificate with serial number %v for %v but the server presented the certificate with serial number %v for %v",
+			certificate.SerialNumber, certificate.DNSNames, served.SerialNumber, served.DNSNames)
+	}
+
+	return nil
+}
+
+// AssertCertificateChain returns an error if the certificate chain presented by the server
+// cannot be verified for hostname using roots as the trusted certificate authorities
+func (s *Scenario) AssertCertificateChain(roots *x509.CertPool, hostname string) error {
+	if s.CapturedResponse == nil || len(s.CapturedResponse.Certificates) == 0 {
+		return fmt.Errorf("certificate verification requires executing a request and also target an HTTPS URL")
+	}
+
+	intermediates := x509.NewCertPool()
+	for _, certificate := range s.CapturedResponse.Certificates[1:] {
+		intermediates.AddCert(certificate)
+	}
+
+	_, err := s.CapturedResponse.Certificate.Verify(x509.VerifyOptions{
+		DNSName:       hostname,
+		Roots:         roots,
+		Intermediates: intermediates,
+	})
+	if err != nil {
+		return fmt.Errorf("verifying the certificate chain presented by the server (%v certificates): %w", len(s.CapturedResponse.Certificates), err)
+	}
+
+	return nil
+}
+
 // AssertResponseCertificate returns nil if the captured certificate for the named host is valid.
 // Otherwise it returns an error describing the mismatch.
 func (s *Scenario) AssertResponseCertificate(hostname string) error {